@@ -9,6 +9,7 @@ import (
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/scheduler"
 	"github.com/disgoorg/disgo/sharding"
 	"github.com/disgoorg/disgo/voice"
 	"github.com/disgoorg/snowflake/v2"
@@ -24,6 +25,11 @@ type Client interface {
 	Logger() *slog.Logger
 
 	// Close will clean up all disgo internals and close the discord gracefully.
+	// It disconnects from voice, stops accepting new gateway & http events, waits for running EventListener(s) and scheduled jobs,
+	// flushes pending rest requests and closes the gateway.Gateway(s) until the context.Context is done.
+	// Close must not be called from an EventListener without a context.Context deadline, as it waits for the EventListener to return.
+	// By default, the gateway.Gateway(s) are closed with a resumable close code, see WithShutdownCloseCode.
+	// The session of each gateway.Gateway can then be resumed with gateway.WithSessionID & gateway.WithSequence afterward.
 	Close(ctx context.Context)
 
 	// Token returns the configured bot token.
@@ -44,6 +50,9 @@ type Client interface {
 	// AddEventListeners adds one or more EventListener(s) to the EventManager.
 	AddEventListeners(listeners ...EventListener)
 
+	// AddEventListener adds the EventListener with the given ListenerPriority to the EventManager and returns a func which removes it again.
+	AddEventListener(listener EventListener, priority ListenerPriority) func()
+
 	// RemoveEventListeners removes one or more EventListener(s) from the EventManager
 	RemoveEventListeners(listeners ...EventListener)
 
@@ -104,6 +113,10 @@ type Client interface {
 	// MemberChunkingManager returns the MemberChunkingManager used by the Client.
 	MemberChunkingManager() MemberChunkingManager
 
+	// Scheduler returns the scheduler.Scheduler used by the Client.
+	// This is nil unless it was configured with WithDefaultScheduler, WithSchedulerConfigOpts or WithScheduler.
+	Scheduler() scheduler.Scheduler
+
 	// OpenHTTPServer starts the configured HTTPServer used for interactions over webhooks.
 	OpenHTTPServer() error
 
@@ -134,6 +147,10 @@ type clientImpl struct {
 	caches cache.Caches
 
 	memberChunkingManager MemberChunkingManager
+
+	scheduler scheduler.Scheduler
+
+	shutdownCloseCode int
 }
 
 func (c *clientImpl) Logger() *slog.Logger {
@@ -141,21 +158,44 @@ func (c *clientImpl) Logger() *slog.Logger {
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
+	// voice connections wait for their voice state update from the gateway, so they need to be closed while events are still dispatched
 	if c.voiceManager != nil {
 		c.voiceManager.Close(ctx)
 	}
+
+	// stop accepting new events, gateway events received from now on are replayed when the session is resumed
 	if c.gateway != nil {
-		c.gateway.Close(ctx)
+		c.gateway.StopDispatch()
+	}
+	if c.shardManager != nil {
+		for _, shard := range c.shardManager.Shards() {
+			shard.StopDispatch()
+		}
+	}
+	if c.httpServer != nil {
+		c.httpServer.Close(ctx)
+	}
+
+	// wait for running event listeners & jobs, so they can finish their requests
+	if c.eventManager != nil {
+		c.eventManager.Close(ctx)
 	}
+	if c.scheduler != nil {
+		c.scheduler.Close(ctx)
+	}
+
+	// flush pending requests
 	if c.restServices != nil {
 		c.restServices.Close(ctx)
 	}
+
+	// by default the close code is resumable, so the sessions can be resumed by the next process. See WithShutdownCloseCode
+	if c.gateway != nil {
+		c.gateway.CloseWithCode(ctx, c.shutdownCloseCode, "shutting down")
+	}
 	if c.shardManager != nil {
 		c.shardManager.Close(ctx)
 	}
-	if c.httpServer != nil {
-		c.httpServer.Close(ctx)
-	}
 }
 
 func (c *clientImpl) Token() string {
@@ -185,6 +225,10 @@ func (c *clientImpl) AddEventListeners(listeners ...EventListener) {
 	c.eventManager.AddEventListeners(listeners...)
 }
 
+func (c *clientImpl) AddEventListener(listener EventListener, priority ListenerPriority) func() {
+	return c.eventManager.AddEventListener(listener, priority)
+}
+
 func (c *clientImpl) RemoveEventListeners(listeners ...EventListener) {
 	c.eventManager.RemoveEventListeners(listeners...)
 }
@@ -323,6 +367,10 @@ func (c *clientImpl) MemberChunkingManager() MemberChunkingManager {
 	return c.memberChunkingManager
 }
 
+func (c *clientImpl) Scheduler() scheduler.Scheduler {
+	return c.scheduler
+}
+
 func (c *clientImpl) OpenHTTPServer() error {
 	if c.httpServer == nil {
 		return discord.ErrNoHTTPServer
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/disgoorg/disgo/cache"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
 	"github.com/disgoorg/disgo/internal/tokenhelper"
+	"github.com/disgoorg/disgo/metrics"
 	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/scheduler"
 	"github.com/disgoorg/disgo/sharding"
 	"github.com/disgoorg/disgo/voice"
 )
@@ -20,6 +24,7 @@ func DefaultConfig(gatewayHandlers map[gateway.EventType]GatewayEventHandler, ht
 		Logger:                 slog.Default(),
 		EventManagerConfigOpts: []EventManagerConfigOpt{WithGatewayHandlers(gatewayHandlers), WithHTTPServerHandler(httpHandler)},
 		MemberChunkingFilter:   MemberChunkingFilterNone,
+		ShutdownCloseCode:      websocket.CloseServiceRestart,
 	}
 }
 
@@ -52,6 +57,15 @@ type Config struct {
 
 	MemberChunkingManager MemberChunkingManager
 	MemberChunkingFilter  MemberChunkingFilter
+
+	Scheduler           scheduler.Scheduler
+	SchedulerConfigOpts []scheduler.ConfigOpt
+
+	Metrics metrics.Recorder
+
+	// ShutdownCloseCode is the websocket close code the gateway and the default sharding.ShardManager are closed with by Client.Close.
+	// Defaults to websocket.CloseServiceRestart which keeps the sessions resumable.
+	ShutdownCloseCode int
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Client.
@@ -208,6 +222,43 @@ func WithMemberChunkingFilter(memberChunkingFilter MemberChunkingFilter) ConfigO
 	}
 }
 
+// WithScheduler lets you inject your own scheduler.Scheduler.
+func WithScheduler(scheduler scheduler.Scheduler) ConfigOpt {
+	return func(config *Config) {
+		config.Scheduler = scheduler
+	}
+}
+
+// WithDefaultScheduler creates a scheduler.Scheduler with sensible defaults.
+func WithDefaultScheduler() ConfigOpt {
+	return func(config *Config) {
+		config.SchedulerConfigOpts = append(config.SchedulerConfigOpts, func(_ *scheduler.Config) {})
+	}
+}
+
+// WithSchedulerConfigOpts lets you configure the default scheduler.Scheduler.
+func WithSchedulerConfigOpts(opts ...scheduler.ConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.SchedulerConfigOpts = append(config.SchedulerConfigOpts, opts...)
+	}
+}
+
+// WithShutdownCloseCode sets the websocket close code the gateway and the default sharding.ShardManager are closed with by Client.Close.
+// Use websocket.CloseNormalClosure to invalidate the sessions instead of keeping them resumable.
+func WithShutdownCloseCode(closeCode int) ConfigOpt {
+	return func(config *Config) {
+		config.ShutdownCloseCode = closeCode
+	}
+}
+
+// WithMetrics lets you inject a metrics.Recorder which is passed to the default rest.Client, gateway.Gateway(s) and cache.Caches.
+// Use metrics.NewPrometheusExporter to expose the metrics to Prometheus.
+func WithMetrics(recorder metrics.Recorder) ConfigOpt {
+	return func(config *Config) {
+		config.Metrics = recorder
+	}
+}
+
 // BuildClient creates a new Client instance with the given token, Config, gateway handlers, http handlers os, name, github & version.
 func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client Client) gateway.EventHandlerFunc, httpServerEventHandlerFunc func(client Client) httpserver.EventHandlerFunc, os string, name string, github string, version string) (Client, error) {
 	if token == "" {
@@ -218,17 +269,23 @@ func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client
 		return nil, fmt.Errorf("error while getting application id from token: %w", err)
 	}
 	client := &clientImpl{
-		token:  token,
-		logger: cfg.Logger,
+		token:             token,
+		logger:            cfg.Logger,
+		shutdownCloseCode: cfg.ShutdownCloseCode,
 	}
 
 	client.applicationID = *id
 
+	if cfg.Metrics == nil {
+		cfg.Metrics = metrics.Noop
+	}
+
 	if cfg.RestClient == nil {
 		// prepend standard user-agent. this can be overridden as it's appended to the front of the slice
 		cfg.RestClientConfigOpts = append([]rest.ConfigOpt{
 			rest.WithUserAgent(fmt.Sprintf("DiscordBot (%s, %s)", github, version)),
 			rest.WithLogger(client.logger),
+			rest.WithMetrics(cfg.Metrics),
 			func(config *rest.Config) {
 				config.RateLimiterConfigOpts = append([]rest.RateLimiterConfigOpt{rest.WithRateLimiterLogger(cfg.Logger)}, config.RateLimiterConfigOpts...)
 			},
@@ -265,6 +322,7 @@ func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client
 			gateway.WithOS(os),
 			gateway.WithBrowser(name),
 			gateway.WithDevice(name),
+			gateway.WithMetrics(cfg.Metrics),
 			func(config *gateway.Config) {
 				config.RateLimiterConfigOpts = append([]gateway.RateLimiterConfigOpt{gateway.WithRateLimiterLogger(cfg.Logger)}, config.RateLimiterConfigOpts...)
 			},
@@ -295,11 +353,13 @@ func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client
 				gateway.WithOS(os),
 				gateway.WithBrowser(name),
 				gateway.WithDevice(name),
+				gateway.WithMetrics(cfg.Metrics),
 				func(config *gateway.Config) {
 					config.RateLimiterConfigOpts = append([]gateway.RateLimiterConfigOpt{gateway.WithRateLimiterLogger(cfg.Logger)}, config.RateLimiterConfigOpts...)
 				},
 			),
 			sharding.WithLogger(cfg.Logger),
+			sharding.WithCloseCode(cfg.ShutdownCloseCode),
 			func(config *sharding.Config) {
 				config.RateLimiterConfigOpts = append([]sharding.RateLimiterConfigOpt{sharding.WithRateLimiterLogger(cfg.Logger), sharding.WithMaxConcurrency(gatewayBotRs.SessionStartLimit.MaxConcurrency)}, config.RateLimiterConfigOpts...)
 			},
@@ -327,6 +387,12 @@ func BuildClient(token string, cfg *Config, gatewayEventHandlerFunc func(client
 		cfg.Caches = cache.New(cfg.CacheConfigOpts...)
 	}
 	client.caches = cfg.Caches
+	cfg.Metrics.RegisterCacheSizes(cfg.Caches.Sizes)
+
+	if cfg.Scheduler == nil && len(cfg.SchedulerConfigOpts) > 0 {
+		cfg.Scheduler = scheduler.New(append([]scheduler.ConfigOpt{scheduler.WithLogger(cfg.Logger)}, cfg.SchedulerConfigOpts...)...)
+	}
+	client.scheduler = cfg.Scheduler
 
 	return client, nil
 }
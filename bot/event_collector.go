@@ -2,45 +2,206 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"time"
 )
 
+// ErrCollectorIdle is returned by EventCollector.Err when the EventCollector stopped because of its idle timeout.
+var ErrCollectorIdle = errors.New("event collector idle timeout")
+
 // WaitForEvent waits for an event passing the filterFunc and then calls the actionFunc. You can cancel this function with the passed context.Context and the cancelFunc gets called then.
 func WaitForEvent[E Event](client Client, ctx context.Context, filterFunc func(e E) bool, actionFunc func(e E), cancelFunc func()) {
-	ch, cancel := NewEventCollector(client, filterFunc)
+	collector := CollectEvents(ctx, client, filterFunc, WithCollectorMax(1))
 
-	select {
-	case <-ctx.Done():
-		cancel()
-		if cancelFunc != nil {
-			cancelFunc()
-		}
-	case e := <-ch:
-		cancel()
+	if e, ok := <-collector.Events(); ok {
 		if actionFunc != nil {
 			actionFunc(e)
 		}
+		return
+	}
+	if cancelFunc != nil {
+		cancelFunc()
 	}
 }
 
 // NewEventCollector returns a channel in which the events of type T gets sent which pass the passed filter and a function which can be used to stop the event collector.
 // The close function needs to be called to stop the event collector.
+// See CollectEvents for more options.
 func NewEventCollector[E Event](client Client, filterFunc func(e E) bool) (<-chan E, func()) {
-	ch := make(chan E)
-	var once sync.Once
+	collector := CollectEvents(context.Background(), client, filterFunc)
+	return collector.Events(), collector.Stop
+}
+
+// CollectEvents starts a new EventCollector which collects all events of type E passing the filterFunc until
+// the context.Context is cancelled, EventCollector.Stop is called or one of the limits configured with the EventCollectorConfigOpt(s) is reached.
+// A nil filterFunc collects all events of type E.
+//
+// To collect multiple event types, use a shared interface like Event as E and switch on the type in the filterFunc:
+//
+//	collector := bot.CollectEvents(ctx, client, func(e bot.Event) bool {
+//		switch e := e.(type) {
+//		case *events.GuildMessageCreate:
+//			return e.ChannelID == channelID
+//		case *events.GuildMessageReactionAdd:
+//			return e.ChannelID == channelID
+//		}
+//		return false
+//	}, bot.WithCollectorTimeout(time.Minute))
+func CollectEvents[E Event](ctx context.Context, client Client, filterFunc func(e E) bool, opts ...EventCollectorConfigOpt) *EventCollector[E] {
+	cfg := DefaultEventCollectorConfig()
+	cfg.Apply(opts)
+
+	c := &EventCollector[E]{
+		config:     *cfg,
+		filterFunc: filterFunc,
+		events:     make(chan E, cfg.BufferSize),
+		done:       make(chan struct{}),
+	}
+
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		c.cancelCtx = cancel
+	}
+	if cfg.IdleTimeout > 0 {
+		c.mu.Lock()
+		c.idleTimer = time.AfterFunc(cfg.IdleTimeout, func() {
+			c.stop(ErrCollectorIdle)
+		})
+		c.mu.Unlock()
+	}
+
+	c.listenerMu.Lock()
+	c.removeListener = client.EventManager().AddEventListener(NewListenerFunc(c.onEvent), cfg.Priority)
+	select {
+	case <-c.done:
+		// the idle timeout already stopped the collector
+		c.removeListener()
+	default:
+	}
+	c.listenerMu.Unlock()
 
-	handler := NewListenerFunc(func(e E) {
-		if !filterFunc(e) {
-			return
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.stop(ctx.Err())
+		case <-c.done:
 		}
-		ch <- e
+	}()
+
+	return c
+}
+
+// EventCollector collects events of type E. Create one with CollectEvents.
+type EventCollector[E Event] struct {
+	config     EventCollectorConfig
+	filterFunc func(e E) bool
+	cancelCtx  context.CancelFunc
+	idleTimer  *time.Timer
+
+	listenerMu     sync.Mutex
+	removeListener func()
+
+	mu     sync.Mutex
+	events chan E
+	count  int
+
+	stopOnce sync.Once
+	done     chan struct{}
+	err      error
+}
+
+// Events returns the channel the collected events are sent to. The channel is closed when the EventCollector stops.
+func (c *EventCollector[E]) Events() <-chan E {
+	return c.events
+}
+
+// Done returns a channel which is closed when the EventCollector stops.
+func (c *EventCollector[E]) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the EventCollector stopped. It returns nil if it is still running, was stopped by calling Stop or collected the maximum number of events.
+// Otherwise, it returns the error of the context.Context, context.DeadlineExceeded for timeouts or ErrCollectorIdle.
+func (c *EventCollector[E]) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+// Collect reads all events until the EventCollector stops and returns them together with EventCollector.Err.
+// This should not be used together with Events.
+func (c *EventCollector[E]) Collect() ([]E, error) {
+	var events []E
+	for e := range c.events {
+		events = append(events, e)
+	}
+	return events, c.Err()
+}
+
+// Stop stops the EventCollector, removes its EventListener and closes the events channel. Calling Stop multiple times is safe.
+func (c *EventCollector[E]) Stop() {
+	c.stop(nil)
+}
+
+func (c *EventCollector[E]) stop(err error) {
+	c.stopOnce.Do(func() {
+		c.err = err
+		// closing done first unblocks onEvent, so we can acquire the lock
+		close(c.done)
+
+		c.listenerMu.Lock()
+		if c.removeListener != nil {
+			c.removeListener()
+		}
+		c.listenerMu.Unlock()
+		if c.cancelCtx != nil {
+			c.cancelCtx()
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.idleTimer != nil {
+			c.idleTimer.Stop()
+		}
+		close(c.events)
 	})
-	client.EventManager().AddEventListeners(handler)
+}
 
-	return ch, func() {
-		once.Do(func() {
-			client.EventManager().RemoveEventListeners(handler)
-			close(ch)
-		})
+func (c *EventCollector[E]) onEvent(e E) {
+	if c.filterFunc != nil && !c.filterFunc(e) {
+		return
+	}
+
+	if c.deliver(e) {
+		c.stop(nil)
+	}
+}
+
+// deliver sends the event to the events channel and returns whether the maximum number of events is reached.
+func (c *EventCollector[E]) deliver(e E) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.events <- e:
+	case <-c.done:
+		return false
+	}
+
+	c.count++
+	if c.idleTimer != nil {
+		c.idleTimer.Reset(c.config.IdleTimeout)
 	}
+	return c.config.Max > 0 && c.count >= c.config.Max
 }
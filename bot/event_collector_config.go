@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"time"
+)
+
+// DefaultEventCollectorConfig returns a new EventCollectorConfig with all default values.
+func DefaultEventCollectorConfig() *EventCollectorConfig {
+	return &EventCollectorConfig{
+		Priority: ListenerPriorityNormal,
+	}
+}
+
+// EventCollectorConfig can be used to configure an EventCollector.
+type EventCollectorConfig struct {
+	// Max is the number of events after which the EventCollector stops. Defaults to 0 (unlimited).
+	Max int
+	// Timeout is the duration after which the EventCollector stops. Defaults to 0 (no timeout).
+	Timeout time.Duration
+	// IdleTimeout is the duration without a collected event after which the EventCollector stops. Defaults to 0 (no idle timeout).
+	IdleTimeout time.Duration
+	// BufferSize is the size of the channel the collected events are sent to. Defaults to 0 (unbuffered).
+	BufferSize int
+	// Priority is the ListenerPriority of the EventListener used by the EventCollector. Defaults to ListenerPriorityNormal.
+	Priority ListenerPriority
+}
+
+// EventCollectorConfigOpt is a functional option for configuring an EventCollector.
+type EventCollectorConfigOpt func(config *EventCollectorConfig)
+
+// Apply applies the given EventCollectorConfigOpt(s) to the EventCollectorConfig.
+func (c *EventCollectorConfig) Apply(opts []EventCollectorConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithCollectorMax stops the EventCollector after the given number of events were collected.
+func WithCollectorMax(max int) EventCollectorConfigOpt {
+	return func(config *EventCollectorConfig) {
+		config.Max = max
+	}
+}
+
+// WithCollectorTimeout stops the EventCollector after the given duration.
+func WithCollectorTimeout(timeout time.Duration) EventCollectorConfigOpt {
+	return func(config *EventCollectorConfig) {
+		config.Timeout = timeout
+	}
+}
+
+// WithCollectorIdleTimeout stops the EventCollector if no event was collected for the given duration.
+func WithCollectorIdleTimeout(idleTimeout time.Duration) EventCollectorConfigOpt {
+	return func(config *EventCollectorConfig) {
+		config.IdleTimeout = idleTimeout
+	}
+}
+
+// WithCollectorBufferSize sets the buffer size of the channel the collected events are sent to.
+// When the buffer is full, the EventManager waits until the event is read or the EventCollector stops.
+func WithCollectorBufferSize(bufferSize int) EventCollectorConfigOpt {
+	return func(config *EventCollectorConfig) {
+		config.BufferSize = bufferSize
+	}
+}
+
+// WithCollectorPriority sets the ListenerPriority of the EventListener used by the EventCollector.
+func WithCollectorPriority(priority ListenerPriority) EventCollectorConfigOpt {
+	return func(config *EventCollectorConfig) {
+		config.Priority = priority
+	}
+}
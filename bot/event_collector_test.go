@@ -0,0 +1,73 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	Client
+	eventManager EventManager
+}
+
+func (c *testClient) EventManager() EventManager {
+	return c.eventManager
+}
+
+func newTestClient() *testClient {
+	client := &testClient{}
+	client.eventManager = NewEventManager(client)
+	return client
+}
+
+func TestCollectEventsMax(t *testing.T) {
+	client := newTestClient()
+
+	collector := CollectEvents(context.Background(), client, func(e *testEvent) bool {
+		return e.n%2 == 0
+	}, WithCollectorMax(2), WithCollectorBufferSize(2))
+
+	for i := 0; i < 5; i++ {
+		client.EventManager().DispatchEvent(&testEvent{n: i})
+	}
+
+	events, err := collector.Collect()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.False(t, IsListening[*testEvent](client.EventManager()), "the listener should be removed after the collector stopped")
+}
+
+func TestCollectEventsTimeouts(t *testing.T) {
+	client := newTestClient()
+
+	collector := CollectEvents[*testEvent](context.Background(), client, nil, WithCollectorIdleTimeout(10*time.Millisecond))
+	_, err := collector.Collect()
+	assert.ErrorIs(t, err, ErrCollectorIdle)
+
+	collector = CollectEvents[*testEvent](context.Background(), client, nil, WithCollectorTimeout(10*time.Millisecond))
+	_, err = collector.Collect()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCollectEventsUnread(t *testing.T) {
+	client := newTestClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	CollectEvents[*testEvent](ctx, client, nil)
+
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		client.EventManager().DispatchEvent(&testEvent{})
+	}()
+
+	cancel()
+	select {
+	case <-dispatched:
+	case <-time.After(time.Second):
+		t.Fatal("dispatching an event should not block after the collector was cancelled")
+	}
+}
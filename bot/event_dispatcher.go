@@ -0,0 +1,229 @@
+package bot
+
+import (
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// BackpressureStrategy defines what the event dispatcher does when its queue is full.
+type BackpressureStrategy int
+
+const (
+	// BackpressureStrategyBlock blocks the caller of EventManager.DispatchEvent until there is space in the queue or the EventManager is closed.
+	// This also blocks the gateway.Gateway from reading new events.
+	// Dispatching events from an EventListener running on a worker can deadlock once the queue of the worker is full,
+	// as the worker waits for itself. Use a go routine or another strategy in this case.
+	BackpressureStrategyBlock BackpressureStrategy = iota
+	// BackpressureStrategyDrop silently drops events which don't fit into the queue.
+	BackpressureStrategyDrop
+	// BackpressureStrategyLog drops events which don't fit into the queue and logs a warning.
+	BackpressureStrategyLog
+)
+
+func (s BackpressureStrategy) String() string {
+	switch s {
+	case BackpressureStrategyBlock:
+		return "block"
+	case BackpressureStrategyDrop:
+		return "drop"
+	case BackpressureStrategyLog:
+		return "log"
+	}
+	return "unknown"
+}
+
+// EventOrderKeyFunc returns the key of an Event which is used by the event dispatcher to keep the order of events.
+// Events with the same key are always processed by the same worker in the order they were dispatched.
+// Events for which false is returned can be processed by any worker.
+type EventOrderKeyFunc func(event Event) (uint64, bool)
+
+// GuildEventOrderKey is an EventOrderKeyFunc which orders events per guild.
+// It uses the GuildID field or method of the event, which can either be a snowflake.ID or a *snowflake.ID.
+func GuildEventOrderKey(event Event) (uint64, bool) {
+	guildID, ok := eventGuildID(event)
+	return uint64(guildID), ok
+}
+
+var guildIDAccessors sync.Map // map[reflect.Type]func(reflect.Value) (snowflake.ID, bool)
+
+func eventGuildID(event Event) (snowflake.ID, bool) {
+	if event == nil {
+		return 0, false
+	}
+	v := reflect.ValueOf(event)
+	accessor, ok := guildIDAccessors.Load(v.Type())
+	if !ok {
+		accessor, _ = guildIDAccessors.LoadOrStore(v.Type(), newGuildIDAccessor(v.Type()))
+	}
+	return accessor.(func(reflect.Value) (snowflake.ID, bool))(v)
+}
+
+var (
+	snowflakeType    = reflect.TypeOf(snowflake.ID(0))
+	snowflakePtrType = reflect.TypeOf((*snowflake.ID)(nil))
+)
+
+func newGuildIDAccessor(t reflect.Type) func(reflect.Value) (snowflake.ID, bool) {
+	if method, ok := t.MethodByName("GuildID"); ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+		return func(v reflect.Value) (id snowflake.ID, ok bool) {
+			// the method can be promoted from a nil embedded pointer
+			defer func() {
+				if recover() != nil {
+					id, ok = 0, false
+				}
+			}()
+			return guildIDFromValue(v.Method(method.Index).Call(nil)[0])
+		}
+	}
+
+	structType := t
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return func(reflect.Value) (snowflake.ID, bool) { return 0, false }
+	}
+	field, ok := structType.FieldByName("GuildID")
+	if !ok || (field.Type != snowflakeType && field.Type != snowflakePtrType) {
+		return func(reflect.Value) (snowflake.ID, bool) { return 0, false }
+	}
+	return func(v reflect.Value) (snowflake.ID, bool) {
+		v = reflect.Indirect(v)
+		if !v.IsValid() {
+			return 0, false
+		}
+		fieldValue, err := v.FieldByIndexErr(field.Index)
+		if err != nil {
+			return 0, false
+		}
+		return guildIDFromValue(fieldValue)
+	}
+}
+
+func guildIDFromValue(v reflect.Value) (snowflake.ID, bool) {
+	switch v.Type() {
+	case snowflakeType:
+		id := snowflake.ID(v.Uint())
+		return id, id != 0
+	case snowflakePtrType:
+		if v.IsNil() {
+			return 0, false
+		}
+		id := snowflake.ID(v.Elem().Uint())
+		return id, id != 0
+	}
+	return 0, false
+}
+
+func newEventDispatcher(logger *slog.Logger, workers int, queueSize int, strategy BackpressureStrategy, orderKeyFunc EventOrderKeyFunc) *eventDispatcher {
+	d := &eventDispatcher{
+		logger:       logger,
+		strategy:     strategy,
+		orderKeyFunc: orderKeyFunc,
+		done:         make(chan struct{}),
+	}
+
+	// without ordering all workers share a single queue, with ordering every worker has its own queue
+	queues := 1
+	if orderKeyFunc != nil {
+		queues = workers
+	}
+	d.queues = make([]chan func(), queues)
+	for i := range d.queues {
+		d.queues[i] = make(chan func(), queueSize)
+	}
+
+	for i := 0; i < workers; i++ {
+		d.wg.Add(1)
+		go d.work(d.queues[i%queues])
+	}
+	return d
+}
+
+type eventDispatcher struct {
+	logger       *slog.Logger
+	strategy     BackpressureStrategy
+	orderKeyFunc EventOrderKeyFunc
+	queues       []chan func()
+	next         atomic.Uint64
+	wg           sync.WaitGroup
+
+	// closeMu guards closed and adding to sending, so close can't close the queues while dispatch sends to them
+	closeMu sync.RWMutex
+	closed  bool
+	sending sync.WaitGroup
+	// done is closed by close to release blocked dispatch calls
+	done chan struct{}
+}
+
+func (d *eventDispatcher) work(queue <-chan func()) {
+	defer d.wg.Done()
+	for f := range queue {
+		f()
+	}
+}
+
+func (d *eventDispatcher) queue(event Event) chan func() {
+	if len(d.queues) == 1 {
+		return d.queues[0]
+	}
+	if key, ok := d.orderKeyFunc(event); ok {
+		return d.queues[key%uint64(len(d.queues))]
+	}
+	return d.queues[d.next.Add(1)%uint64(len(d.queues))]
+}
+
+// close stops all workers after they processed all queued events.
+// Blocked dispatch calls are released and events dispatched afterward are dropped.
+func (d *eventDispatcher) close() {
+	d.closeMu.Lock()
+	if d.closed {
+		d.closeMu.Unlock()
+		return
+	}
+	d.closed = true
+	d.closeMu.Unlock()
+
+	close(d.done)
+	d.sending.Wait()
+	for _, queue := range d.queues {
+		close(queue)
+	}
+}
+
+// dispatch queues f for the given event and returns false if it was dropped.
+func (d *eventDispatcher) dispatch(event Event, f func()) bool {
+	d.closeMu.RLock()
+	if d.closed {
+		d.closeMu.RUnlock()
+		return false
+	}
+	d.sending.Add(1)
+	d.closeMu.RUnlock()
+	defer d.sending.Done()
+
+	queue := d.queue(event)
+	if d.strategy == BackpressureStrategyBlock {
+		select {
+		case queue <- f:
+			return true
+		case <-d.done:
+			d.logger.Warn("event manager closed while waiting for space in the event queue, dropping event", slog.String("type", reflect.TypeOf(event).String()))
+			return false
+		}
+	}
+
+	select {
+	case queue <- f:
+		return true
+	default:
+		if d.strategy == BackpressureStrategyLog {
+			d.logger.Warn("event queue is full, dropping event", slog.String("type", reflect.TypeOf(event).String()))
+		}
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	GuildID snowflake.ID
+	n       int
+}
+
+func (e *testEvent) Client() Client      { return nil }
+func (e *testEvent) SequenceNumber() int { return e.n }
+
+type testEmbeddedEvent struct {
+	*testEvent
+}
+
+type testMethodEvent struct {
+	testEvent
+	guildID *snowflake.ID
+}
+
+func (e *testMethodEvent) GuildID() *snowflake.ID { return e.guildID }
+
+func TestGuildEventOrderKey(t *testing.T) {
+	key, ok := GuildEventOrderKey(&testEvent{GuildID: 1})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), key)
+
+	key, ok = GuildEventOrderKey(&testEmbeddedEvent{testEvent: &testEvent{GuildID: 2}})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), key)
+
+	_, ok = GuildEventOrderKey(&testEmbeddedEvent{})
+	assert.False(t, ok, "nil embedded structs should not have a key")
+
+	guildID := snowflake.ID(3)
+	key, ok = GuildEventOrderKey(&testMethodEvent{guildID: &guildID})
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), key)
+
+	_, ok = GuildEventOrderKey(&testMethodEvent{})
+	assert.False(t, ok)
+}
+
+func TestEventDispatcherOrdering(t *testing.T) {
+	d := newEventDispatcher(slog.Default(), 4, 16, BackpressureStrategyBlock, GuildEventOrderKey)
+
+	var (
+		mu       sync.Mutex
+		received = map[snowflake.ID][]int{}
+		wg       sync.WaitGroup
+	)
+	for i := 0; i < 100; i++ {
+		event := &testEvent{GuildID: snowflake.ID(i%5 + 1), n: i}
+		wg.Add(1)
+		d.dispatch(event, func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			received[event.GuildID] = append(received[event.GuildID], event.n)
+		})
+	}
+	wg.Wait()
+
+	for guildID, numbers := range received {
+		assert.IsIncreasing(t, numbers, "events of guild %d should be processed in order", guildID)
+	}
+}
+
+func TestEventDispatcherDrop(t *testing.T) {
+	d := newEventDispatcher(slog.Default(), 1, 1, BackpressureStrategyDrop, nil)
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	assert.True(t, d.dispatch(&testEvent{}, func() {
+		close(started)
+		<-block
+	}))
+	<-started
+	assert.True(t, d.dispatch(&testEvent{}, func() {}), "the queue should have space for one event")
+	assert.False(t, d.dispatch(&testEvent{}, func() {}), "the event should be dropped when the queue is full")
+	close(block)
+}
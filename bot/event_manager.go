@@ -1,9 +1,14 @@
 package bot
 
 import (
+	"context"
 	"log/slog"
+	"reflect"
 	"runtime/debug"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/disgo/httpserver"
@@ -17,14 +22,22 @@ func NewEventManager(client Client, opts ...EventManagerConfigOpt) EventManager
 	cfg.Apply(opts)
 	cfg.Logger = cfg.Logger.With(slog.String("name", "bot_event_manager"))
 
-	return &eventManagerImpl{
+	var dispatcher *eventDispatcher
+	if cfg.WorkerPoolSize > 0 {
+		dispatcher = newEventDispatcher(cfg.Logger, cfg.WorkerPoolSize, cfg.WorkerQueueSize, cfg.BackpressureStrategy, cfg.EventOrderKeyFunc)
+	}
+
+	m := &eventManagerImpl{
 		client:             client,
 		logger:             cfg.Logger,
-		eventListeners:     cfg.EventListeners,
+		eventTypes:         map[reflect.Type]int{},
 		asyncEventsEnabled: cfg.AsyncEventsEnabled,
+		dispatcher:         dispatcher,
 		gatewayHandlers:    cfg.GatewayHandlers,
 		httpServerHandler:  cfg.HTTPServerHandler,
 	}
+	m.AddEventListeners(cfg.EventListeners...)
+	return m
 }
 
 // EventManager lets you listen for specific events triggered by raw gateway events
@@ -32,9 +45,18 @@ type EventManager interface {
 	// AddEventListeners adds one or more EventListener(s) to the EventManager
 	AddEventListeners(eventListeners ...EventListener)
 
-	// RemoveEventListeners removes one or more EventListener(s) from the EventManager
+	// AddEventListener adds the EventListener with the given ListenerPriority and returns a func which removes it again.
+	// Adding the same EventListener multiple times registers it multiple times, each returned func only removes its own registration.
+	AddEventListener(eventListener EventListener, priority ListenerPriority) func()
+
+	// RemoveEventListeners removes one or more EventListener(s) from the EventManager.
+	// EventListener(s) are compared by equality, prefer the func returned by AddEventListener.
 	RemoveEventListeners(eventListeners ...EventListener)
 
+	// HasEventListeners returns whether any EventListener is interested in events of the given type.
+	// EventListener(s) which don't implement TypedEventListener are interested in all events.
+	HasEventListeners(eventType reflect.Type) bool
+
 	// HandleGatewayEvent calls the correct GatewayEventHandler for the payload
 	HandleGatewayEvent(gatewayEventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData)
 
@@ -43,6 +65,11 @@ type EventManager interface {
 
 	// DispatchEvent dispatches a new Event to the Client's EventListener(s)
 	DispatchEvent(event Event)
+
+	// Close stops handling new gateway & http events and dispatching new Event(s).
+	// It waits for all running EventListener(s), including async ones and queued events of the worker pool, until the context.Context is done.
+	// Close must not be called from an EventListener without a context.Context deadline, as it waits for the EventListener to return.
+	Close(ctx context.Context)
 }
 
 // EventListener is used to create new EventListener to listen to events
@@ -50,6 +77,51 @@ type EventListener interface {
 	OnEvent(event Event)
 }
 
+// TypedEventListener can be implemented by an EventListener which only handles a single Event type.
+// The EventManager uses this to skip events nobody listens to. All listeners created by NewListenerFunc, NewConsumerFunc and NewListenerChan implement it.
+type TypedEventListener interface {
+	EventListener
+	// EventType returns the type of the Event the EventListener handles or nil if it handles all events.
+	EventType() reflect.Type
+}
+
+// IsListening returns whether any EventListener of the EventManager is interested in events of type E.
+// This can be used to skip building events which are expensive to create.
+// The default gateway handlers use it to skip the events of high volume gateway events like messages, reactions, typing, voice states, members and guilds.
+func IsListening[E Event](eventManager EventManager) bool {
+	return eventManager.HasEventListeners(reflect.TypeOf((*E)(nil)).Elem())
+}
+
+// eventTypeOf returns the concrete type of E or nil if E is an interface and can match multiple event types.
+func eventTypeOf[E Event]() reflect.Type {
+	eventType := reflect.TypeOf((*E)(nil)).Elem()
+	if eventType.Kind() == reflect.Interface {
+		return nil
+	}
+	return eventType
+}
+
+// EventConsumer can be implemented by an EventListener to stop an Event from being passed to EventListener(s) with a lower ListenerPriority.
+// If an EventListener implements EventConsumer, ConsumeEvent is called instead of EventListener.OnEvent.
+// Consuming events has no effect if async events are enabled, see WithAsyncEventsEnabled.
+type EventConsumer interface {
+	// ConsumeEvent handles the Event and returns true if it should not be passed to any further EventListener(s).
+	ConsumeEvent(event Event) bool
+}
+
+// ListenerPriority defines the order in which EventListener(s) are called. EventListener(s) with a higher priority are called first.
+// EventListener(s) with the same priority are called in the order they were added.
+type ListenerPriority int
+
+// Common ListenerPriority(s), any other value can be used too.
+const (
+	ListenerPriorityLowest  ListenerPriority = -200
+	ListenerPriorityLow     ListenerPriority = -100
+	ListenerPriorityNormal  ListenerPriority = 0
+	ListenerPriorityHigh    ListenerPriority = 100
+	ListenerPriorityHighest ListenerPriority = 200
+)
+
 // NewListenerFunc returns a new EventListener for the given func(e E)
 func NewListenerFunc[E Event](f func(e E)) EventListener {
 	return &listenerFunc[E]{f: f}
@@ -59,12 +131,41 @@ type listenerFunc[E Event] struct {
 	f func(e E)
 }
 
+func (l *listenerFunc[E]) EventType() reflect.Type {
+	return eventTypeOf[E]()
+}
+
 func (l *listenerFunc[E]) OnEvent(e Event) {
 	if event, ok := e.(E); ok {
 		l.f(event)
 	}
 }
 
+// NewConsumerFunc returns a new EventListener for the given func(e E) bool which implements EventConsumer.
+// If f returns true the event is not passed to EventListener(s) with a lower ListenerPriority.
+func NewConsumerFunc[E Event](f func(e E) bool) EventListener {
+	return &consumerFunc[E]{f: f}
+}
+
+type consumerFunc[E Event] struct {
+	f func(e E) bool
+}
+
+func (l *consumerFunc[E]) EventType() reflect.Type {
+	return eventTypeOf[E]()
+}
+
+func (l *consumerFunc[E]) OnEvent(e Event) {
+	l.ConsumeEvent(e)
+}
+
+func (l *consumerFunc[E]) ConsumeEvent(e Event) bool {
+	if event, ok := e.(E); ok {
+		return l.f(event)
+	}
+	return false
+}
+
 // NewListenerChan returns a new EventListener for the given chan<- Event
 func NewListenerChan[E Event](c chan<- E) EventListener {
 	return &listenerChan[E]{c: c}
@@ -74,6 +175,10 @@ type listenerChan[E Event] struct {
 	c chan<- E
 }
 
+func (l *listenerChan[E]) EventType() reflect.Type {
+	return eventTypeOf[E]()
+}
+
 func (l *listenerChan[E]) OnEvent(e Event) {
 	if event, ok := e.(E); ok {
 		l.c <- event
@@ -120,16 +225,28 @@ type HTTPServerEventHandler interface {
 type eventManagerImpl struct {
 	mu sync.Mutex
 
-	client             Client
-	logger             *slog.Logger
-	eventListenerMu    sync.Mutex
-	eventListeners     []EventListener
+	// closeMu guards closed and adding to inFlight, so Close can't miss an event which is being dispatched
+	closeMu  sync.RWMutex
+	closed   bool
+	inFlight sync.WaitGroup
+
+	client          Client
+	logger          *slog.Logger
+	eventListenerMu sync.Mutex
+	eventListeners  []*listenerEntry
+	// eventTypes counts the TypedEventListener(s) per event type, the nil key counts all other EventListener(s)
+	eventTypes         map[reflect.Type]int
 	asyncEventsEnabled bool
+	dispatcher         *eventDispatcher
 	gatewayHandlers    map[gateway.EventType]GatewayEventHandler
 	httpServerHandler  HTTPServerEventHandler
 }
 
 func (e *eventManagerImpl) HandleGatewayEvent(gatewayEventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+	if e.isClosed() {
+		e.logger.Debug("dropping gateway event after close", slog.Any("event_type", gatewayEventType), slog.Int("shard_id", shardID))
+		return
+	}
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	if handler, ok := e.gatewayHandlers[gatewayEventType]; ok {
@@ -140,52 +257,225 @@ func (e *eventManagerImpl) HandleGatewayEvent(gatewayEventType gateway.EventType
 }
 
 func (e *eventManagerImpl) HandleHTTPEvent(respondFunc httpserver.RespondFunc, event httpserver.EventInteractionCreate, original []byte) {
+	if e.isClosed() {
+		e.logger.Debug("dropping http event after close")
+		return
+	}
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	e.httpServerHandler.HandleHTTPEvent(e.client, respondFunc, event, original)
 }
 
 func (e *eventManagerImpl) DispatchEvent(event Event) {
+	e.eventListenerMu.Lock()
+	listeners := e.eventListeners
+	listening := e.hasEventListeners(reflect.TypeOf(event))
+	e.eventListenerMu.Unlock()
+	if !listening {
+		return
+	}
+
+	e.closeMu.RLock()
+	if e.closed {
+		e.closeMu.RUnlock()
+		return
+	}
+
+	if e.dispatcher != nil {
+		// the lock is not held while queueing, as a full queue blocks until Close releases it
+		e.inFlight.Add(1)
+		e.closeMu.RUnlock()
+		if !e.dispatcher.dispatch(event, func() {
+			defer e.inFlight.Done()
+			e.callListeners(listeners, event)
+		}) {
+			e.inFlight.Done()
+		}
+		return
+	}
+
+	if e.asyncEventsEnabled {
+		e.inFlight.Add(len(listeners))
+		e.closeMu.RUnlock()
+		for _, entry := range listeners {
+			go func(listener EventListener) {
+				defer e.inFlight.Done()
+				e.callListener(listener, event)
+			}(entry.listener)
+		}
+		return
+	}
+
+	e.inFlight.Add(1)
+	e.closeMu.RUnlock()
+	defer e.inFlight.Done()
+	e.callListeners(listeners, event)
+}
+
+func (e *eventManagerImpl) isClosed() bool {
+	e.closeMu.RLock()
+	defer e.closeMu.RUnlock()
+	return e.closed
+}
+
+// closeWarnTimeout is how long Close waits for event listeners without a deadline before it warns about it.
+var closeWarnTimeout = 10 * time.Second
+
+func (e *eventManagerImpl) Close(ctx context.Context) {
+	e.closeMu.Lock()
+	if e.closed {
+		e.closeMu.Unlock()
+		return
+	}
+	e.closed = true
+	e.closeMu.Unlock()
+
+	if e.dispatcher != nil {
+		e.dispatcher.close()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.inFlight.Wait()
+		close(done)
+	}()
+	var slow <-chan time.Time
+	if _, ok := ctx.Deadline(); !ok {
+		timer := time.NewTimer(closeWarnTimeout)
+		defer timer.Stop()
+		slow = timer.C
+	}
+	for {
+		select {
+		case <-done:
+			return
+		case <-slow:
+			e.logger.Warn("still waiting for event listeners to return, Close must not be called from an event listener without a deadline", slog.Duration("waited", closeWarnTimeout))
+		case <-ctx.Done():
+			e.logger.Warn("closing event manager while event listeners are still running", slog.Any("err", ctx.Err()))
+			return
+		}
+	}
+}
+
+func (e *eventManagerImpl) callListeners(listeners []*listenerEntry, event Event) {
+	for _, entry := range listeners {
+		if entry.removed.Load() {
+			continue
+		}
+		if e.callListener(entry.listener, event) {
+			return
+		}
+	}
+}
+
+// callListener calls the EventListener and returns whether it consumed the event.
+func (e *eventManagerImpl) callListener(listener EventListener, event Event) (consumed bool) {
 	defer func() {
 		if r := recover(); r != nil {
 			e.logger.Error("recovered from panic in event listener", slog.Any("arg", r), slog.String("stack", string(debug.Stack())))
-			return
 		}
 	}()
-	e.eventListenerMu.Lock()
-	defer e.eventListenerMu.Unlock()
-	for i := range e.eventListeners {
-		if e.asyncEventsEnabled {
-			go func(i int) {
-				defer func() {
-					if r := recover(); r != nil {
-						e.logger.Error("recovered from panic in event listener", slog.Any("arg", r), slog.String("stack", string(debug.Stack())))
-						return
-					}
-				}()
-				e.eventListeners[i].OnEvent(event)
-			}(i)
-			continue
-		}
-		e.eventListeners[i].OnEvent(event)
+	if consumer, ok := listener.(EventConsumer); ok {
+		return consumer.ConsumeEvent(event)
 	}
+	listener.OnEvent(event)
+	return false
 }
 
 func (e *eventManagerImpl) AddEventListeners(listeners ...EventListener) {
+	for _, listener := range listeners {
+		e.AddEventListener(listener, ListenerPriorityNormal)
+	}
+}
+
+func (e *eventManagerImpl) AddEventListener(listener EventListener, priority ListenerPriority) func() {
+	entry := &listenerEntry{
+		listener: listener,
+		priority: priority,
+	}
+
 	e.eventListenerMu.Lock()
 	defer e.eventListenerMu.Unlock()
-	e.eventListeners = append(e.eventListeners, listeners...)
+	// the slice is copied on every change, so DispatchEvent can iterate over it without holding the lock
+	i, _ := slices.BinarySearchFunc(e.eventListeners, priority, func(entry *listenerEntry, priority ListenerPriority) int {
+		if entry.priority >= priority {
+			return -1
+		}
+		return 1
+	})
+	e.eventListeners = slices.Insert(slices.Clip(e.eventListeners), i, entry)
+	e.eventTypes[entry.eventType()]++
+
+	return func() {
+		e.removeEntry(entry)
+	}
 }
 
 func (e *eventManagerImpl) RemoveEventListeners(listeners ...EventListener) {
 	e.eventListenerMu.Lock()
-	defer e.eventListenerMu.Unlock()
+	var entries []*listenerEntry
 	for _, listener := range listeners {
-		for i, l := range e.eventListeners {
-			if l == listener {
-				e.eventListeners = append(e.eventListeners[:i], e.eventListeners[i+1:]...)
+		for _, entry := range e.eventListeners {
+			if entry.listener == listener && !slices.Contains(entries, entry) {
+				entries = append(entries, entry)
 				break
 			}
 		}
 	}
+	e.eventListenerMu.Unlock()
+
+	for _, entry := range entries {
+		e.removeEntry(entry)
+	}
+}
+
+func (e *eventManagerImpl) removeEntry(entry *listenerEntry) {
+	e.eventListenerMu.Lock()
+	defer e.eventListenerMu.Unlock()
+	if i := slices.Index(e.eventListeners, entry); i != -1 {
+		entry.removed.Store(true)
+		e.eventListeners = slices.Delete(slices.Clone(e.eventListeners), i, i+1)
+		if e.eventTypes[entry.eventType()]--; e.eventTypes[entry.eventType()] <= 0 {
+			delete(e.eventTypes, entry.eventType())
+		}
+	}
+}
+
+func (e *eventManagerImpl) HasEventListeners(eventType reflect.Type) bool {
+	e.eventListenerMu.Lock()
+	defer e.eventListenerMu.Unlock()
+	return e.hasEventListeners(eventType)
+}
+
+func (e *eventManagerImpl) hasEventListeners(eventType reflect.Type) bool {
+	if e.eventTypes[nil] > 0 {
+		return true
+	}
+	if eventType == nil {
+		return len(e.eventTypes) > 0
+	}
+	if eventType.Kind() == reflect.Interface {
+		for t := range e.eventTypes {
+			if t.Implements(eventType) {
+				return true
+			}
+		}
+		return false
+	}
+	return e.eventTypes[eventType] > 0
+}
+
+type listenerEntry struct {
+	listener EventListener
+	priority ListenerPriority
+	// removed is set when the listener is removed while an event is being dispatched to a snapshot of the listeners
+	removed atomic.Bool
+}
+
+func (e *listenerEntry) eventType() reflect.Type {
+	if typed, ok := e.listener.(TypedEventListener); ok {
+		return typed.EventType()
+	}
+	return nil
 }
@@ -19,6 +19,11 @@ type EventManagerConfig struct {
 	EventListeners     []EventListener
 	AsyncEventsEnabled bool
 
+	WorkerPoolSize       int
+	WorkerQueueSize      int
+	BackpressureStrategy BackpressureStrategy
+	EventOrderKeyFunc    EventOrderKeyFunc
+
 	GatewayHandlers   map[gateway.EventType]GatewayEventHandler
 	HTTPServerHandler HTTPServerEventHandler
 }
@@ -64,6 +69,36 @@ func WithAsyncEventsEnabled() EventManagerConfigOpt {
 	}
 }
 
+// WithEventWorkerPool dispatches events to a fixed pool of workers with a bounded queue of the given size instead of calling the EventListener(s) directly.
+// Every event is processed by one worker which calls all EventListener(s) one after another.
+// This takes precedence over WithAsyncEventsEnabled.
+func WithEventWorkerPool(workers int, queueSize int) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		config.WorkerPoolSize = workers
+		config.WorkerQueueSize = queueSize
+	}
+}
+
+// WithEventBackpressureStrategy sets what happens when the queue of the event worker pool is full. The default is BackpressureStrategyBlock.
+func WithEventBackpressureStrategy(strategy BackpressureStrategy) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		config.BackpressureStrategy = strategy
+	}
+}
+
+// WithEventOrderKeyFunc sets the EventOrderKeyFunc used by the event worker pool to keep events with the same key in order.
+func WithEventOrderKeyFunc(orderKeyFunc EventOrderKeyFunc) EventManagerConfigOpt {
+	return func(config *EventManagerConfig) {
+		config.EventOrderKeyFunc = orderKeyFunc
+	}
+}
+
+// WithGuildOrderedEvents makes the event worker pool process all events of the same guild in order.
+// See GuildEventOrderKey.
+func WithGuildOrderedEvents() EventManagerConfigOpt {
+	return WithEventOrderKeyFunc(GuildEventOrderKey)
+}
+
 // WithGatewayHandlers overrides the default GatewayEventHandler(s) in the EventManagerConfig.
 func WithGatewayHandlers(handlers map[gateway.EventType]GatewayEventHandler) EventManagerConfigOpt {
 	return func(config *EventManagerConfig) {
@@ -0,0 +1,188 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventManagerPriorities(t *testing.T) {
+	m := NewEventManager(nil)
+
+	var calls []string
+	listener := func(name string) EventListener {
+		return NewListenerFunc(func(e *testEvent) {
+			calls = append(calls, name)
+		})
+	}
+
+	m.AddEventListener(listener("low"), ListenerPriorityLow)
+	m.AddEventListener(listener("normal 1"), ListenerPriorityNormal)
+	m.AddEventListener(listener("high"), ListenerPriorityHigh)
+	m.AddEventListener(listener("normal 2"), ListenerPriorityNormal)
+
+	m.DispatchEvent(&testEvent{})
+	assert.Equal(t, []string{"high", "normal 1", "normal 2", "low"}, calls)
+}
+
+func TestEventManagerConsume(t *testing.T) {
+	m := NewEventManager(nil)
+
+	var calls int
+	m.AddEventListener(NewListenerFunc(func(e *testEvent) {
+		calls++
+	}), ListenerPriorityLow)
+	m.AddEventListener(NewConsumerFunc(func(e *testEvent) bool {
+		return e.n == 1
+	}), ListenerPriorityHigh)
+
+	m.DispatchEvent(&testEvent{n: 0})
+	m.DispatchEvent(&testEvent{n: 1})
+	assert.Equal(t, 1, calls, "consumed events should not be passed to listeners with a lower priority")
+}
+
+func TestEventManagerRemove(t *testing.T) {
+	m := NewEventManager(nil)
+
+	var calls int
+	listener := NewListenerFunc(func(e *testEvent) {
+		calls++
+	})
+	remove1 := m.AddEventListener(listener, ListenerPriorityNormal)
+	remove2 := m.AddEventListener(listener, ListenerPriorityNormal)
+
+	m.DispatchEvent(&testEvent{})
+	assert.Equal(t, 2, calls)
+
+	remove1()
+	remove1()
+	m.DispatchEvent(&testEvent{})
+	assert.Equal(t, 3, calls, "removing a registration twice should not remove other registrations")
+
+	remove2()
+	m.DispatchEvent(&testEvent{})
+	assert.Equal(t, 3, calls)
+}
+
+func TestEventManagerHasEventListeners(t *testing.T) {
+	m := NewEventManager(nil)
+	assert.False(t, IsListening[*testEvent](m))
+
+	var calls int
+	remove := m.AddEventListener(NewListenerFunc(func(e *testEmbeddedEvent) {
+		calls++
+	}), ListenerPriorityNormal)
+	assert.True(t, IsListening[*testEmbeddedEvent](m))
+	assert.False(t, IsListening[*testEvent](m))
+	assert.True(t, IsListening[Event](m))
+
+	m.DispatchEvent(&testEmbeddedEvent{})
+	assert.Equal(t, 1, calls)
+	remove()
+	assert.False(t, IsListening[*testEmbeddedEvent](m))
+
+	m.AddEventListener(NewListenerFunc(func(e Event) {}), ListenerPriorityNormal)
+	assert.True(t, IsListening[*testEvent](m), "listeners for interfaces should receive all events")
+}
+
+func TestEventManagerClose(t *testing.T) {
+	m := NewEventManager(nil, WithEventWorkerPool(1, 10))
+
+	var calls int
+	release := make(chan struct{})
+	m.AddEventListener(NewListenerFunc(func(e *testEvent) {
+		<-release
+		calls++
+	}), ListenerPriorityNormal)
+
+	for i := 0; i < 3; i++ {
+		m.DispatchEvent(&testEvent{})
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		m.Close(context.Background())
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("close should wait for queued events")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	<-closed
+	assert.Equal(t, 3, calls)
+
+	m.DispatchEvent(&testEvent{})
+	assert.Equal(t, 3, calls, "events should not be dispatched after close")
+}
+
+func TestEventManagerCloseReleasesBlockedDispatch(t *testing.T) {
+	m := NewEventManager(nil, WithEventWorkerPool(1, 1), WithEventBackpressureStrategy(BackpressureStrategyBlock))
+
+	blocked := make(chan struct{})
+	m.AddEventListener(NewListenerFunc(func(e *testEvent) {
+		if e.n != 0 {
+			return
+		}
+		// the first event fills the queue and the second one waits for the worker which is dispatching it
+		m.DispatchEvent(&testEvent{n: 1})
+		close(blocked)
+		m.DispatchEvent(&testEvent{n: 2})
+	}), ListenerPriorityNormal)
+	m.DispatchEvent(&testEvent{})
+	<-blocked
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	m.Close(ctx)
+	assert.NoError(t, ctx.Err(), "close should release dispatch calls waiting for the full queue")
+}
+
+type syncWriter struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *syncWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestEventManagerCloseWarnsWithoutDeadline(t *testing.T) {
+	defer func(timeout time.Duration) { closeWarnTimeout = timeout }(closeWarnTimeout)
+	closeWarnTimeout = time.Millisecond
+
+	logs := &syncWriter{}
+	m := NewEventManager(nil, WithEventManagerLogger(slog.New(slog.NewTextHandler(logs, nil))), WithAsyncEventsEnabled())
+	release := make(chan struct{})
+	m.AddEventListener(NewListenerFunc(func(e *testEvent) {
+		<-release
+	}), ListenerPriorityNormal)
+	m.DispatchEvent(&testEvent{})
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		m.Close(context.Background())
+	}()
+	assert.Eventually(t, func() bool {
+		return strings.Contains(logs.String(), "still waiting for event listeners")
+	}, time.Second, time.Millisecond)
+	close(release)
+	<-closed
+}
@@ -37,10 +37,17 @@ var _ Cache[any] = (*DefaultCache[any])(nil)
 // This cache implementation is thread safe and can be used in multiple goroutines without any issues.
 // It also only hands out copies to the entities. Regardless these entities should be handles as immutable.
 func NewCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) Cache[T] {
+	return NewCacheWithChangeFeed(flags, neededFlags, policy, nil)
+}
+
+// NewCacheWithChangeFeed returns a new DefaultCache implementation like NewCache which additionally reports every mutation to the given ChangeFeed.
+// The neededFlags are used as Change.Cache.
+func NewCacheWithChangeFeed[T any](flags Flags, neededFlags Flags, policy Policy[T], feed ChangeFeed) Cache[T] {
 	return &DefaultCache[T]{
 		flags:       flags,
 		neededFlags: neededFlags,
 		policy:      policy,
+		feed:        feed,
 		cache:       make(map[snowflake.ID]T),
 	}
 }
@@ -48,9 +55,11 @@ func NewCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) Cache[T]
 // DefaultCache is a simple thread safe cache key value store.
 type DefaultCache[T any] struct {
 	mu          sync.RWMutex
+	seq         changeSequencer
 	flags       Flags
 	neededFlags Flags
 	policy      Policy[T]
+	feed        ChangeFeed
 	cache       map[snowflake.ID]T
 }
 
@@ -69,28 +78,65 @@ func (c *DefaultCache[T]) Put(id snowflake.ID, entity T) {
 		return
 	}
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	oldEntity, hadOld := c.cache[id]
 	c.cache[id] = entity
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	emitPut(c.feed, c.neededFlags, 0, id, oldEntity, hadOld, entity)
 }
 
 func (c *DefaultCache[T]) Remove(id snowflake.ID) (T, bool) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	entity, ok := c.cache[id]
 	if ok {
 		delete(c.cache, id)
 	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return entity, ok
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	if ok {
+		emitRemove(c.feed, c.neededFlags, 0, id, entity)
+	}
 	return entity, ok
 }
 
 func (c *DefaultCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	var removed map[snowflake.ID]T
+	if c.feed != nil && c.feed.HasChangeListeners() {
+		removed = make(map[snowflake.ID]T)
+	}
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	for id, entity := range c.cache {
 		if filterFunc(entity) {
 			delete(c.cache, id)
+			if removed != nil {
+				removed[id] = entity
+			}
 		}
 	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	for id, entity := range removed {
+		emitRemove(c.feed, c.neededFlags, 0, id, entity)
+	}
 }
 
 func (c *DefaultCache[T]) Len() int {
@@ -29,6 +29,9 @@ func DefaultConfig() *Config {
 type Config struct {
 	CacheFlags Flags
 
+	ChangeFeed      ChangeFeed
+	ChangeListeners []ChangeListener
+
 	SelfUserCache SelfUserCache
 
 	GuildCache       GuildCache
@@ -55,8 +58,10 @@ type Config struct {
 	ThreadMemberCache       ThreadMemberCache
 	ThreadMemberCachePolicy Policy[discord.ThreadMember]
 
-	PresenceCache       PresenceCache
-	PresenceCachePolicy Policy[discord.Presence]
+	PresenceCache         PresenceCache
+	PresenceCachePolicy   Policy[discord.Presence]
+	CompactPresenceCache  bool
+	CompactPresenceFields PresenceFields
 
 	VoiceStateCache       VoiceStateCache
 	VoiceStateCachePolicy Policy[discord.VoiceState]
@@ -79,47 +84,56 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.ChangeFeed == nil {
+		c.ChangeFeed = NewChangeFeed()
+	}
+	for _, listener := range c.ChangeListeners {
+		c.ChangeFeed.AddChangeListener(listener)
+	}
 	if c.SelfUserCache == nil {
 		c.SelfUserCache = NewSelfUserCache()
 	}
 	if c.GuildCache == nil {
-		c.GuildCache = NewGuildCache(NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
+		c.GuildCache = NewGuildCache(NewCacheWithChangeFeed[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy, c.ChangeFeed), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
 	}
 	if c.ChannelCache == nil {
-		c.ChannelCache = NewChannelCache(NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy))
+		c.ChannelCache = NewChannelCache(NewCacheWithChangeFeed[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy, c.ChangeFeed))
 	}
 	if c.StageInstanceCache == nil {
-		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy))
+		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCacheWithChangeFeed[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy, c.ChangeFeed))
 	}
 	if c.GuildScheduledEventCache == nil {
-		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
+		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCacheWithChangeFeed[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy, c.ChangeFeed))
 	}
 	if c.GuildSoundboardSoundCache == nil {
-		c.GuildSoundboardSoundCache = NewGuildSoundboardSoundCache(NewGroupedCache[discord.SoundboardSound](c.CacheFlags, FlagGuildSoundboardSounds, c.GuildSoundboardSoundCachePolicy))
+		c.GuildSoundboardSoundCache = NewGuildSoundboardSoundCache(NewGroupedCacheWithChangeFeed[discord.SoundboardSound](c.CacheFlags, FlagGuildSoundboardSounds, c.GuildSoundboardSoundCachePolicy, c.ChangeFeed))
 	}
 	if c.RoleCache == nil {
-		c.RoleCache = NewRoleCache(NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy))
+		c.RoleCache = NewRoleCache(NewGroupedCacheWithChangeFeed[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy, c.ChangeFeed))
 	}
 	if c.MemberCache == nil {
-		c.MemberCache = NewMemberCache(NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy))
+		c.MemberCache = NewMemberCache(NewGroupedCacheWithChangeFeed[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy, c.ChangeFeed))
 	}
 	if c.ThreadMemberCache == nil {
-		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy))
+		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCacheWithChangeFeed[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy, c.ChangeFeed))
+	}
+	if c.PresenceCache == nil && c.CompactPresenceCache {
+		c.PresenceCache = NewCompactPresenceCache(c.CacheFlags, c.PresenceCachePolicy, c.CompactPresenceFields, c.ChangeFeed)
 	}
 	if c.PresenceCache == nil {
-		c.PresenceCache = NewPresenceCache(NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy))
+		c.PresenceCache = NewPresenceCache(NewGroupedCacheWithChangeFeed[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy, c.ChangeFeed))
 	}
 	if c.VoiceStateCache == nil {
-		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy))
+		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCacheWithChangeFeed[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy, c.ChangeFeed))
 	}
 	if c.MessageCache == nil {
-		c.MessageCache = NewMessageCache(NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy))
+		c.MessageCache = NewMessageCache(NewGroupedCacheWithChangeFeed[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy, c.ChangeFeed))
 	}
 	if c.EmojiCache == nil {
-		c.EmojiCache = NewEmojiCache(NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy))
+		c.EmojiCache = NewEmojiCache(NewGroupedCacheWithChangeFeed[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy, c.ChangeFeed))
 	}
 	if c.StickerCache == nil {
-		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
+		c.StickerCache = NewStickerCache(NewGroupedCacheWithChangeFeed[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy, c.ChangeFeed))
 	}
 }
 
@@ -130,6 +144,21 @@ func WithCaches(flags ...Flags) ConfigOpt {
 	}
 }
 
+// WithChangeFeed sets the ChangeFeed of the Config.
+// The default caches report all their mutations to it.
+func WithChangeFeed(changeFeed ChangeFeed) ConfigOpt {
+	return func(config *Config) {
+		config.ChangeFeed = changeFeed
+	}
+}
+
+// WithChangeListeners adds the given ChangeListener(s) to the ChangeFeed of the Config.
+func WithChangeListeners(listeners ...ChangeListener) ConfigOpt {
+	return func(config *Config) {
+		config.ChangeListeners = append(config.ChangeListeners, listeners...)
+	}
+}
+
 // WithGuildCachePolicy sets the Policy[discord.Guild] of the Config.
 func WithGuildCachePolicy(policy Policy[discord.Guild]) ConfigOpt {
 	return func(config *Config) {
@@ -249,6 +278,15 @@ func WithPresenceCache(presenceCache PresenceCache) ConfigOpt {
 	}
 }
 
+// WithCompactPresenceCache lets the Config use a PresenceCache created by NewCompactPresenceCache which only keeps the given PresenceFields.
+// This drastically reduces the memory usage of FlagPresences in large guilds.
+func WithCompactPresenceCache(fields PresenceFields) ConfigOpt {
+	return func(config *Config) {
+		config.CompactPresenceCache = true
+		config.CompactPresenceFields = fields
+	}
+}
+
 // WithVoiceStateCachePolicy sets the Policy[discord.VoiceState] of the Config.
 func WithVoiceStateCachePolicy(policy Policy[discord.VoiceState]) ConfigOpt {
 	return func(config *Config) {
@@ -2,7 +2,6 @@ package cache
 
 import (
 	"sync"
-	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -812,6 +811,10 @@ type Caches interface {
 	EmojiCache
 	StickerCache
 
+	// ChangeFeed receives every mutation of the default entity caches.
+	// Use AddChangeListener to get notified about them.
+	ChangeFeed
+
 	// CacheFlags returns the current configured FLags of the caches.
 	CacheFlags() Flags
 
@@ -820,13 +823,36 @@ type Caches interface {
 	MemberPermissions(member discord.Member) discord.Permissions
 
 	// MemberPermissionsInChannel returns the calculated permissions of the given member in the given channel.
-	// This requires the FlagRoles and FlagChannels to be set.
+	// Threads inherit the permission overwrites of their parent channel, timeouts and Discord's implicit permissions are applied.
+	// If FlagThreadMembers is set, members which are not part of a private thread and can't manage threads get no permissions in it.
+	// If the parent channel of a thread is not cached, only administrators get permissions in it.
+	// This requires the FlagRoles and FlagChannels to be set. See discord.ComputeChannelPermissions to compute permissions without a cache.
 	MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions
 
 	// MemberRoles returns all roles of the given member.
 	// This requires the FlagRoles to be set.
 	MemberRoles(member discord.Member) []discord.Role
 
+	// HighestRole returns the highest role of the given member in the role hierarchy.
+	// Members without roles return the @everyone role. The bool is false if no role was found in the RoleCache.
+	// This requires the FlagRoles to be set.
+	HighestRole(member discord.Member) (discord.Role, bool)
+
+	// CanModerate returns whether the actor is allowed to moderate (kick, ban, timeout, ...) the target and a ModerationReason if not.
+	// The guild owner can moderate everyone, all other actors need a higher highest role than the target and the given discord.Permissions.
+	// The administrator permission does not bypass the role hierarchy.
+	// This requires the FlagGuilds and FlagRoles to be set.
+	CanModerate(actor discord.Member, target discord.Member, permissions ...discord.Permissions) (bool, ModerationReason)
+
+	// CanManageRole returns whether the member is allowed to edit or assign the given role and a ModerationReason if not.
+	// This requires discord.PermissionManageRoles and a highest role which is higher than the given role, unless the member is the guild owner.
+	// Roles managed by an integration can't be assigned by anyone.
+	// This requires the FlagGuilds and FlagRoles to be set.
+	CanManageRole(member discord.Member, role discord.Role) (bool, ModerationReason)
+
+	// Sizes returns the number of cached entities per cache type, for example "guilds" or "members".
+	Sizes() map[string]int
+
 	// AudioChannelMembers returns all members which are in the given audio channel.
 	// This requires the FlagVoiceStates to be set.
 	AudioChannelMembers(channel discord.GuildAudioChannel) []discord.Member
@@ -899,6 +925,7 @@ func New(opts ...ConfigOpt) Caches {
 		messageCache:              config.MessageCache,
 		emojiCache:                config.EmojiCache,
 		stickerCache:              config.StickerCache,
+		changeFeed:                config.ChangeFeed,
 	}
 }
 
@@ -918,6 +945,7 @@ type (
 	emojiCache                = EmojiCache
 	stickerCache              = StickerCache
 	selfUserCache             = SelfUserCache
+	changeFeed                = ChangeFeed
 )
 
 type cachesImpl struct {
@@ -937,6 +965,7 @@ type cachesImpl struct {
 	emojiCache
 	stickerCache
 	selfUserCache
+	changeFeed
 }
 
 func (c *cachesImpl) CacheFlags() Flags {
@@ -944,67 +973,48 @@ func (c *cachesImpl) CacheFlags() Flags {
 }
 
 func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
-	if guild, ok := c.Guild(member.GuildID); ok && guild.OwnerID == member.User.ID {
-		return discord.PermissionsAll
-	}
-
-	var permissions discord.Permissions
-	if publicRole, ok := c.Role(member.GuildID, member.GuildID); ok {
-		permissions = publicRole.Permissions
-	}
-
-	for _, role := range c.MemberRoles(member) {
-		permissions = permissions.Add(role.Permissions)
-		if permissions.Has(discord.PermissionAdministrator) {
-			return discord.PermissionsAll
-		}
-	}
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
+	permissions := discord.ComputeBasePermissions(c.guildOwnerID(member.GuildID), member, c.memberRolesWithPublicRole(member))
+	if permissions.Missing(discord.PermissionAdministrator) && member.IsCommunicationDisabled() {
+		permissions &= discord.PermissionsTimedOut
 	}
 	return permissions
 }
 
 func (c *cachesImpl) MemberPermissionsInChannel(channel discord.GuildChannel, member discord.Member) discord.Permissions {
-	permissions := c.MemberPermissions(member)
-	if permissions.Has(discord.PermissionAdministrator) {
-		return discord.PermissionsAll
-	}
-
-	var (
-		allow discord.Permissions
-		deny  discord.Permissions
-	)
-
-	if overwrite, ok := channel.PermissionOverwrites().Role(channel.GuildID()); ok {
-		permissions |= overwrite.Allow
-		permissions &= ^overwrite.Deny
-	}
-
-	for _, roleID := range member.RoleIDs {
-		if roleID == channel.GuildID() {
-			continue
+	var parent discord.GuildChannel
+	if thread, ok := channel.(discord.GuildThread); ok && thread.ParentID() != nil {
+		var parentOk bool
+		if parent, parentOk = c.Channel(*thread.ParentID()); !parentOk {
+			// without the overwrites of the parent channel only administrators are known to have access to the thread
+			if permissions := c.MemberPermissions(member); permissions.Has(discord.PermissionAdministrator) {
+				return permissions
+			}
+			return discord.PermissionsNone
 		}
+	}
 
-		if overwrite, ok := channel.PermissionOverwrites().Role(roleID); ok {
-			allow |= overwrite.Allow
-			deny |= overwrite.Deny
+	permissions := discord.ComputeChannelPermissions(c.guildOwnerID(member.GuildID), member, c.memberRolesWithPublicRole(member), channel, parent)
+	if channel.Type() == discord.ChannelTypeGuildPrivateThread && c.CacheFlags().Has(FlagThreadMembers) && permissions.Missing(discord.PermissionManageThreads) {
+		if _, ok := c.ThreadMember(channel.ID(), member.User.ID); !ok {
+			return discord.PermissionsNone
 		}
 	}
+	return permissions
+}
 
-	if overwrite, ok := channel.PermissionOverwrites().Member(member.User.ID); ok {
-		allow |= overwrite.Allow
-		deny |= overwrite.Deny
+func (c *cachesImpl) guildOwnerID(guildID snowflake.ID) snowflake.ID {
+	if guild, ok := c.Guild(guildID); ok {
+		return guild.OwnerID
 	}
+	return 0
+}
 
-	permissions &= ^deny
-	permissions |= allow
-
-	if member.CommunicationDisabledUntil != nil && member.CommunicationDisabledUntil.After(time.Now()) {
-		permissions &= discord.PermissionViewChannel | discord.PermissionReadMessageHistory
+func (c *cachesImpl) memberRolesWithPublicRole(member discord.Member) []discord.Role {
+	roles := c.MemberRoles(member)
+	if publicRole, ok := c.Role(member.GuildID, member.GuildID); ok {
+		roles = append(roles, publicRole)
 	}
-
-	return permissions
+	return roles
 }
 
 func (c *cachesImpl) MemberRoles(member discord.Member) []discord.Role {
@@ -1019,6 +1029,92 @@ func (c *cachesImpl) MemberRoles(member discord.Member) []discord.Role {
 	return roles
 }
 
+func (c *cachesImpl) HighestRole(member discord.Member) (discord.Role, bool) {
+	var (
+		highestRole discord.Role
+		found       bool
+	)
+	for _, role := range c.memberRolesWithPublicRole(member) {
+		if !found || role.Compare(highestRole) > 0 {
+			highestRole = role
+			found = true
+		}
+	}
+	return highestRole, found
+}
+
+func (c *cachesImpl) CanModerate(actor discord.Member, target discord.Member, permissions ...discord.Permissions) (bool, ModerationReason) {
+	if actor.GuildID != target.GuildID {
+		return false, ModerationReasonDifferentGuild
+	}
+	if actor.User.ID == target.User.ID {
+		return false, ModerationReasonSelf
+	}
+	guild, ok := c.Guild(actor.GuildID)
+	if !ok {
+		return false, ModerationReasonGuildNotCached
+	}
+	if target.User.ID == guild.OwnerID {
+		return false, ModerationReasonTargetOwner
+	}
+	if actor.User.ID == guild.OwnerID {
+		return true, ModerationReasonNone
+	}
+	if c.MemberPermissions(actor).Missing(permissions...) {
+		return false, ModerationReasonMissingPermissions
+	}
+
+	actorRole, _ := c.HighestRole(actor)
+	targetRole, _ := c.HighestRole(target)
+	if actorRole.Compare(targetRole) <= 0 {
+		return false, ModerationReasonRoleHierarchy
+	}
+	return true, ModerationReasonNone
+}
+
+func (c *cachesImpl) CanManageRole(member discord.Member, role discord.Role) (bool, ModerationReason) {
+	if member.GuildID != role.GuildID {
+		return false, ModerationReasonDifferentGuild
+	}
+	if role.Managed {
+		return false, ModerationReasonManagedRole
+	}
+	guild, ok := c.Guild(member.GuildID)
+	if !ok {
+		return false, ModerationReasonGuildNotCached
+	}
+	if member.User.ID == guild.OwnerID {
+		return true, ModerationReasonNone
+	}
+	if c.MemberPermissions(member).Missing(discord.PermissionManageRoles) {
+		return false, ModerationReasonMissingPermissions
+	}
+
+	highestRole, _ := c.HighestRole(member)
+	if highestRole.Compare(role) <= 0 {
+		return false, ModerationReasonRoleHierarchy
+	}
+	return true, ModerationReasonNone
+}
+
+func (c *cachesImpl) Sizes() map[string]int {
+	return map[string]int{
+		"guilds":                  c.GuildsLen(),
+		"channels":                c.ChannelsLen(),
+		"stage_instances":         c.StageInstancesAllLen(),
+		"guild_scheduled_events":  c.GuildScheduledEventsAllLen(),
+		"guild_soundboard_sounds": c.GuildSoundboardSoundsAllLen(),
+		"roles":                   c.RolesAllLen(),
+		"members":                 c.MembersAllLen(),
+		"thread_members":          c.ThreadMembersAllLen(),
+		"presences":               c.PresencesAllLen(),
+		"voice_states":            c.VoiceStatesAllLen(),
+		"messages":                c.MessagesAllLen(),
+		"emojis":                  c.EmojisAllLen(),
+		"stickers":                c.StickersAllLen(),
+	}
+}
+
 func (c *cachesImpl) AudioChannelMembers(channel discord.GuildAudioChannel) []discord.Member {
 	var members []discord.Member
 	c.VoiceStatesForEach(channel.GuildID(), func(state discord.VoiceState) {
@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestMemberPermissionsInThreadWithoutParent(t *testing.T) {
+	const (
+		guildID snowflake.ID = 1
+		ownerID snowflake.ID = 10
+	)
+	var channel discord.UnmarshalChannel
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"3","guild_id":"1","type":11,"parent_id":"2","name":"thread","thread_metadata":{}}`), &channel))
+	thread := channel.Channel.(discord.GuildThread)
+
+	caches := New(WithCaches(FlagGuilds | FlagRoles | FlagChannels))
+	caches.AddGuild(discord.Guild{ID: guildID, OwnerID: ownerID})
+	caches.AddRole(discord.Role{ID: guildID, GuildID: guildID, Permissions: discord.PermissionViewChannel | discord.PermissionSendMessagesInThreads})
+	caches.AddRole(discord.Role{ID: 4, GuildID: guildID, Permissions: discord.PermissionAdministrator})
+
+	member := discord.Member{User: discord.User{ID: 11}, GuildID: guildID}
+	assert.Equal(t, discord.PermissionsNone, caches.MemberPermissionsInChannel(thread, member), "denies of the missing parent channel could apply")
+
+	admin := discord.Member{User: discord.User{ID: 12}, GuildID: guildID, RoleIDs: []snowflake.ID{4}}
+	assert.Equal(t, discord.PermissionsAll, caches.MemberPermissionsInChannel(thread, admin))
+
+	owner := discord.Member{User: discord.User{ID: ownerID}, GuildID: guildID}
+	assert.Equal(t, discord.PermissionsAll, caches.MemberPermissionsInChannel(thread, owner))
+}
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// FieldChange describes a single field which differs between two versions of an entity.
+// Pointer fields are dereferenced, so Old and New are nil if the field was not set.
+type FieldChange struct {
+	// Field is the json name of the changed field, e.g. "nick" or "permissions".
+	Field string
+	Old   any
+	New   any
+}
+
+// String returns the FieldChange formatted as "field: old -> new".
+func (c FieldChange) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Old, c.New)
+}
+
+// DiffMember returns all fields which differ between the old and new discord.Member.
+// discord.Member.RoleIDs are compared regardless of their order.
+func DiffMember(old discord.Member, new discord.Member) []FieldChange {
+	var changes []FieldChange
+	changes = diffPtr(changes, "nick", old.Nick, new.Nick)
+	changes = diffPtr(changes, "avatar", old.Avatar, new.Avatar)
+	changes = diffPtr(changes, "banner", old.Banner, new.Banner)
+	if added, removed := DiffIDs(old.RoleIDs, new.RoleIDs); len(added) > 0 || len(removed) > 0 {
+		changes = append(changes, FieldChange{Field: "roles", Old: old.RoleIDs, New: new.RoleIDs})
+	}
+	changes = diffTime(changes, "premium_since", old.PremiumSince, new.PremiumSince)
+	changes = diffValue(changes, "deaf", old.Deaf, new.Deaf)
+	changes = diffValue(changes, "mute", old.Mute, new.Mute)
+	changes = diffValue(changes, "flags", old.Flags, new.Flags)
+	changes = diffValue(changes, "pending", old.Pending, new.Pending)
+	changes = diffTime(changes, "communication_disabled_until", old.CommunicationDisabledUntil, new.CommunicationDisabledUntil)
+	changes = diffPtr(changes, "avatar_decoration_data", old.AvatarDecorationData, new.AvatarDecorationData)
+	return changes
+}
+
+// DiffRole returns all fields which differ between the old and new discord.Role.
+func DiffRole(old discord.Role, new discord.Role) []FieldChange {
+	var changes []FieldChange
+	changes = diffValue(changes, "name", old.Name, new.Name)
+	changes = diffPtr(changes, "description", old.Description, new.Description)
+	changes = diffValue(changes, "color", old.Color, new.Color)
+	changes = diffValue(changes, "hoist", old.Hoist, new.Hoist)
+	changes = diffValue(changes, "position", old.Position, new.Position)
+	changes = diffValue(changes, "permissions", old.Permissions, new.Permissions)
+	changes = diffValue(changes, "managed", old.Managed, new.Managed)
+	changes = diffPtr(changes, "icon", old.Icon, new.Icon)
+	changes = diffPtr(changes, "unicode_emoji", old.Emoji, new.Emoji)
+	changes = diffValue(changes, "mentionable", old.Mentionable, new.Mentionable)
+	changes = diffValue(changes, "flags", old.Flags, new.Flags)
+	return changes
+}
+
+// DiffGuildChannel returns all fields which differ between the old and new discord.GuildChannel.
+// Fields of discord.GuildMessageChannel and discord.GuildAudioChannel are only compared if both channels implement them.
+// discord.PermissionOverwrites are compared regardless of their order.
+func DiffGuildChannel(old discord.GuildChannel, new discord.GuildChannel) []FieldChange {
+	var changes []FieldChange
+	changes = diffValue(changes, "type", old.Type(), new.Type())
+	changes = diffValue(changes, "name", old.Name(), new.Name())
+	changes = diffValue(changes, "position", old.Position(), new.Position())
+	changes = diffPtr(changes, "parent_id", old.ParentID(), new.ParentID())
+	if !equalPermissionOverwrites(old.PermissionOverwrites(), new.PermissionOverwrites()) {
+		changes = append(changes, FieldChange{Field: "permission_overwrites", Old: old.PermissionOverwrites(), New: new.PermissionOverwrites()})
+	}
+
+	oldMessageChannel, oldOk := old.(discord.GuildMessageChannel)
+	newMessageChannel, newOk := new.(discord.GuildMessageChannel)
+	if oldOk && newOk {
+		changes = diffPtr(changes, "topic", oldMessageChannel.Topic(), newMessageChannel.Topic())
+		changes = diffValue(changes, "nsfw", oldMessageChannel.NSFW(), newMessageChannel.NSFW())
+		changes = diffValue(changes, "rate_limit_per_user", oldMessageChannel.RateLimitPerUser(), newMessageChannel.RateLimitPerUser())
+		changes = diffValue(changes, "default_auto_archive_duration", oldMessageChannel.DefaultAutoArchiveDuration(), newMessageChannel.DefaultAutoArchiveDuration())
+	}
+
+	oldAudioChannel, oldOk := old.(discord.GuildAudioChannel)
+	newAudioChannel, newOk := new.(discord.GuildAudioChannel)
+	if oldOk && newOk {
+		changes = diffValue(changes, "bitrate", oldAudioChannel.Bitrate(), newAudioChannel.Bitrate())
+		changes = diffValue(changes, "rtc_region", oldAudioChannel.RTCRegion(), newAudioChannel.RTCRegion())
+	}
+	return changes
+}
+
+// DiffIDs returns the IDs which were added to and removed from old to new.
+// This is useful to find out which roles were added to or removed from a discord.Member.
+func DiffIDs(old []snowflake.ID, new []snowflake.ID) (added []snowflake.ID, removed []snowflake.ID) {
+	for _, id := range new {
+		if !slices.Contains(old, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range old {
+		if !slices.Contains(new, id) {
+			removed = append(removed, id)
+		}
+	}
+	return
+}
+
+func diffValue[T comparable](changes []FieldChange, field string, old T, new T) []FieldChange {
+	if old == new {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Old: old, New: new})
+}
+
+func diffPtr[T comparable](changes []FieldChange, field string, old *T, new *T) []FieldChange {
+	if old == nil && new == nil {
+		return changes
+	}
+	if old != nil && new != nil && *old == *new {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Old: derefOrNil(old), New: derefOrNil(new)})
+}
+
+func diffTime(changes []FieldChange, field string, old *time.Time, new *time.Time) []FieldChange {
+	if old == nil && new == nil {
+		return changes
+	}
+	if old != nil && new != nil && old.Equal(*new) {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Old: derefOrNil(old), New: derefOrNil(new)})
+}
+
+func derefOrNil[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func equalPermissionOverwrites(old discord.PermissionOverwrites, new discord.PermissionOverwrites) bool {
+	if len(old) != len(new) {
+		return false
+	}
+	for _, overwrite := range old {
+		other, ok := new.Get(overwrite.Type(), overwrite.ID())
+		if !ok || other != overwrite {
+			return false
+		}
+	}
+	return true
+}
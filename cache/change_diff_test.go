@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestDiffMember(t *testing.T) {
+	nick := "nick"
+	now := time.Now()
+	old := discord.Member{RoleIDs: []snowflake.ID{1, 2}, CommunicationDisabledUntil: &now}
+	new := discord.Member{Nick: &nick, RoleIDs: []snowflake.ID{2, 1}, CommunicationDisabledUntil: json.Ptr(now.Add(0)), Pending: true}
+
+	assert.Equal(t, []FieldChange{
+		{Field: "nick", Old: nil, New: "nick"},
+		{Field: "pending", Old: false, New: true},
+	}, DiffMember(old, new), "role order and equal times should be ignored")
+
+	new.RoleIDs = []snowflake.ID{2, 3}
+	changes := DiffMember(old, new)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "roles", changes[1].Field)
+}
+
+func TestDiffRole(t *testing.T) {
+	changes := DiffRole(
+		discord.Role{Name: "mod", Permissions: discord.PermissionKickMembers},
+		discord.Role{Name: "moderator", Permissions: discord.PermissionKickMembers | discord.PermissionBanMembers},
+	)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "name: mod -> moderator", changes[0].String())
+	assert.Equal(t, FieldChange{Field: "permissions", Old: discord.PermissionKickMembers, New: discord.PermissionKickMembers | discord.PermissionBanMembers}, changes[1])
+	assert.Empty(t, DiffRole(discord.Role{ID: 1}, discord.Role{ID: 1}))
+}
+
+func TestDiffGuildChannel(t *testing.T) {
+	unmarshal := func(data string) discord.GuildChannel {
+		var channel discord.UnmarshalChannel
+		require.NoError(t, json.Unmarshal([]byte(data), &channel))
+		return channel.Channel.(discord.GuildChannel)
+	}
+	old := unmarshal(`{"id":"1","guild_id":"2","type":0,"name":"general","topic":"hi","permission_overwrites":[{"id":"3","type":0,"allow":"0","deny":"1024"},{"id":"4","type":1,"allow":"1024","deny":"0"}]}`)
+	reordered := unmarshal(`{"id":"1","guild_id":"2","type":0,"name":"general","topic":"hi","permission_overwrites":[{"id":"4","type":1,"allow":"1024","deny":"0"},{"id":"3","type":0,"allow":"0","deny":"1024"}]}`)
+	assert.Empty(t, DiffGuildChannel(old, reordered), "overwrite order should be ignored")
+
+	new := unmarshal(`{"id":"1","guild_id":"2","type":0,"name":"chat","nsfw":true,"permission_overwrites":[{"id":"3","type":0,"allow":"0","deny":"0"}]}`)
+	var fields []string
+	for _, change := range DiffGuildChannel(old, new) {
+		fields = append(fields, change.Field)
+	}
+	assert.Equal(t, []string{"name", "permission_overwrites", "topic", "nsfw"}, fields)
+}
+
+func TestDiffIDs(t *testing.T) {
+	added, removed := DiffIDs([]snowflake.ID{1, 2, 3}, []snowflake.ID{3, 4})
+	assert.Equal(t, []snowflake.ID{4}, added)
+	assert.Equal(t, []snowflake.ID{1, 2}, removed)
+}
@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ChangeKind is the kind of mutation a Change describes.
+type ChangeKind int
+
+// values for ChangeKind
+const (
+	ChangeKindCreate ChangeKind = iota
+	ChangeKindUpdate
+	ChangeKindDelete
+)
+
+// String returns a human-readable name of the ChangeKind.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeKindCreate:
+		return "create"
+	case ChangeKindUpdate:
+		return "update"
+	case ChangeKindDelete:
+		return "delete"
+	default:
+		return "unknown"
+	}
+}
+
+// Change describes a single mutation of one of the entity caches.
+type Change struct {
+	// Kind is the kind of mutation.
+	Kind ChangeKind
+	// Cache is the Flags of the cache which was mutated, e.g. FlagMembers.
+	Cache Flags
+	// GroupID is the group the entity is stored in. This is 0 for caches which are not grouped.
+	GroupID snowflake.ID
+	// ID is the key the entity is stored under.
+	ID snowflake.ID
+	// Old is the entity before the mutation. This is nil for ChangeKindCreate.
+	Old any
+	// New is the entity after the mutation. This is nil for ChangeKindDelete.
+	New any
+}
+
+// ChangeListener is called for each Change reported to a ChangeFeed.
+// Changes of the same cache are reported in the order they were applied. A ChangeListener may read the caches,
+// but must not mutate the cache which reported the Change synchronously, as that mutation waits until the ChangeListener returned.
+type ChangeListener func(change Change)
+
+// NewTypedChangeListener returns a ChangeListener which is only called for changes of entities of type T.
+// For ChangeKindCreate old is the zero value of T and for ChangeKindDelete new is the zero value of T.
+func NewTypedChangeListener[T any](f func(change Change, old T, new T)) ChangeListener {
+	return func(change Change) {
+		var oldEntity, newEntity T
+		if change.Old != nil {
+			var ok bool
+			if oldEntity, ok = change.Old.(T); !ok {
+				return
+			}
+		}
+		if change.New != nil {
+			var ok bool
+			if newEntity, ok = change.New.(T); !ok {
+				return
+			}
+		}
+		f(change, oldEntity, newEntity)
+	}
+}
+
+// ChangeFeed receives every mutation of the caches created with it and fans them out to its ChangeListener(s).
+type ChangeFeed interface {
+	// AddChangeListener adds a ChangeListener and returns a func to remove it again.
+	AddChangeListener(listener ChangeListener) func()
+
+	// HasChangeListeners returns whether any ChangeListener is registered.
+	// Caches use this to skip collecting removed entities when nobody is interested in them.
+	HasChangeListeners() bool
+
+	// EmitChange calls all registered ChangeListener(s) with the given Change.
+	EmitChange(change Change)
+}
+
+// NewChangeFeed returns a thread safe in memory implementation of a ChangeFeed.
+// ChangeListener(s) are called synchronously in the order they were added.
+func NewChangeFeed() ChangeFeed {
+	return &changeFeedImpl{}
+}
+
+type changeListenerEntry struct {
+	listener ChangeListener
+}
+
+type changeFeedImpl struct {
+	mu        sync.RWMutex
+	listeners []*changeListenerEntry
+	// count mirrors len(listeners), so HasChangeListeners is cheap on every cache mutation.
+	count atomic.Int32
+}
+
+func (f *changeFeedImpl) AddChangeListener(listener ChangeListener) func() {
+	entry := &changeListenerEntry{listener: listener}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listeners = append(f.listeners, entry)
+	f.count.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			for i, l := range f.listeners {
+				if l == entry {
+					f.listeners = append(f.listeners[:i:i], f.listeners[i+1:]...)
+					f.count.Add(-1)
+					break
+				}
+			}
+		})
+	}
+}
+
+func (f *changeFeedImpl) HasChangeListeners() bool {
+	return f.count.Load() > 0
+}
+
+func (f *changeFeedImpl) EmitChange(change Change) {
+	f.mu.RLock()
+	listeners := f.listeners
+	f.mu.RUnlock()
+
+	for _, l := range listeners {
+		l.listener(change)
+	}
+}
+
+func emitPut[T any](feed ChangeFeed, cacheFlag Flags, groupID snowflake.ID, id snowflake.ID, oldEntity T, hadOld bool, newEntity T) {
+	if feed == nil {
+		return
+	}
+	change := Change{
+		Kind:    ChangeKindCreate,
+		Cache:   cacheFlag,
+		GroupID: groupID,
+		ID:      id,
+		New:     newEntity,
+	}
+	if hadOld {
+		change.Kind = ChangeKindUpdate
+		change.Old = oldEntity
+	}
+	feed.EmitChange(change)
+}
+
+func emitRemove[T any](feed ChangeFeed, cacheFlag Flags, groupID snowflake.ID, id snowflake.ID, oldEntity T) {
+	if feed == nil {
+		return
+	}
+	feed.EmitChange(Change{
+		Kind:    ChangeKindDelete,
+		Cache:   cacheFlag,
+		GroupID: groupID,
+		ID:      id,
+		Old:     oldEntity,
+	})
+}
+
+// changeSequencer orders the emission of changes of a cache. Caches take a ticket while holding their lock and emit their changes
+// after releasing it once all changes of earlier tickets are emitted. This keeps Change.Old and Change.New of concurrent mutations in order
+// while ChangeListener(s) can still read the cache.
+type changeSequencer struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	next    uint64
+	emitted uint64
+}
+
+// ticket must be called while holding the lock of the cache. It returns false without taking a ticket if the feed is nil or has no ChangeListener(s),
+// so caches skip building changes nobody receives. Only if it returns true, the cache must call wait and done.
+func (s *changeSequencer) ticket(feed ChangeFeed) (uint64, bool) {
+	if feed == nil || !feed.HasChangeListeners() {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ticket := s.next
+	s.next++
+	return ticket, true
+}
+
+func (s *changeSequencer) wait(ticket uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cond == nil {
+		s.cond = sync.NewCond(&s.mu)
+	}
+	for s.emitted != ticket {
+		s.cond.Wait()
+	}
+}
+
+func (s *changeSequencer) done() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.emitted++
+	if s.cond != nil {
+		s.cond.Broadcast()
+	}
+}
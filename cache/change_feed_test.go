@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func collectChanges(feed ChangeFeed) (func() []Change, func()) {
+	var (
+		mu      sync.Mutex
+		changes []Change
+	)
+	remove := feed.AddChangeListener(func(change Change) {
+		mu.Lock()
+		defer mu.Unlock()
+		changes = append(changes, change)
+	})
+	return func() []Change {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]Change(nil), changes...)
+	}, remove
+}
+
+func TestCacheChangeFeed(t *testing.T) {
+	feed := NewChangeFeed()
+	changes, remove := collectChanges(feed)
+	c := NewCacheWithChangeFeed[string](FlagGuilds, FlagGuilds, nil, feed)
+
+	c.Put(1, "a")
+	c.Put(1, "b")
+	c.Put(2, "c")
+	_, ok := c.Remove(1)
+	require.True(t, ok)
+	_, ok = c.Remove(1)
+	require.False(t, ok)
+	c.RemoveIf(func(entity string) bool { return entity == "c" })
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeKindCreate, Cache: FlagGuilds, ID: 1, New: "a"},
+		{Kind: ChangeKindUpdate, Cache: FlagGuilds, ID: 1, Old: "a", New: "b"},
+		{Kind: ChangeKindCreate, Cache: FlagGuilds, ID: 2, New: "c"},
+		{Kind: ChangeKindDelete, Cache: FlagGuilds, ID: 1, Old: "b"},
+		{Kind: ChangeKindDelete, Cache: FlagGuilds, ID: 2, Old: "c"},
+	}, changes())
+
+	remove()
+	assert.False(t, feed.HasChangeListeners())
+	c.Put(3, "d")
+	assert.Len(t, changes(), 5, "removed listeners should not be called")
+	assert.Equal(t, uint64(6), c.(*DefaultCache[string]).seq.next, "mutations without listeners should not take a ticket")
+}
+
+func TestCacheChangeFeedFiltered(t *testing.T) {
+	feed := NewChangeFeed()
+	changes, _ := collectChanges(feed)
+
+	NewCacheWithChangeFeed[string](FlagGuilds, FlagRoles, nil, feed).Put(1, "a")
+	NewCacheWithChangeFeed[string](FlagGuilds, FlagGuilds, func(entity string) bool { return entity != "b" }, feed).Put(1, "b")
+	assert.Empty(t, changes(), "entities which are not cached should not be reported")
+}
+
+func TestGroupedCacheChangeFeed(t *testing.T) {
+	feed := NewChangeFeed()
+	changes, _ := collectChanges(feed)
+	c := NewGroupedCacheWithChangeFeed[string](FlagRoles, FlagRoles, nil, feed)
+
+	c.Put(10, 1, "a")
+	c.Put(10, 1, "b")
+	c.Put(20, 2, "c")
+	c.Remove(10, 1)
+	c.GroupRemove(20)
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeKindCreate, Cache: FlagRoles, GroupID: 10, ID: 1, New: "a"},
+		{Kind: ChangeKindUpdate, Cache: FlagRoles, GroupID: 10, ID: 1, Old: "a", New: "b"},
+		{Kind: ChangeKindCreate, Cache: FlagRoles, GroupID: 20, ID: 2, New: "c"},
+		{Kind: ChangeKindDelete, Cache: FlagRoles, GroupID: 10, ID: 1, Old: "b"},
+		{Kind: ChangeKindDelete, Cache: FlagRoles, GroupID: 20, ID: 2, Old: "c"},
+	}, changes())
+
+	c.Put(30, 3, "d")
+	c.Put(30, 4, "e")
+	c.GroupRemoveIf(30, func(_ snowflake.ID, entity string) bool { return entity == "d" })
+	c.RemoveIf(func(_ snowflake.ID, entity string) bool { return entity == "e" })
+	assert.Equal(t, []Change{
+		{Kind: ChangeKindDelete, Cache: FlagRoles, GroupID: 30, ID: 3, Old: "d"},
+		{Kind: ChangeKindDelete, Cache: FlagRoles, GroupID: 30, ID: 4, Old: "e"},
+	}, changes()[7:])
+}
+
+func TestCacheChangeFeedOrder(t *testing.T) {
+	feed := NewChangeFeed()
+	c := NewCacheWithChangeFeed[int](FlagGuilds, FlagGuilds, nil, feed)
+
+	// every update must continue where the previous one ended, even if Put is called concurrently
+	var (
+		mu   sync.Mutex
+		last int
+		ok   = true
+	)
+	feed.AddChangeListener(NewTypedChangeListener(func(change Change, old int, new int) {
+		// give other mutations the chance to overtake this one
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		if old != last {
+			ok = false
+		}
+		last = new
+		_, _ = c.Get(change.ID)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Put(1, i)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.True(t, ok, "changes should be emitted in the order they were applied")
+	entity, _ := c.Get(1)
+	assert.Equal(t, entity, last)
+}
+
+func TestTypedChangeListener(t *testing.T) {
+	var roles []discord.Role
+	listener := NewTypedChangeListener(func(change Change, old discord.Role, new discord.Role) {
+		roles = append(roles, new)
+	})
+	listener(Change{Kind: ChangeKindCreate, New: discord.Role{ID: 1}})
+	listener(Change{Kind: ChangeKindCreate, New: discord.Member{}})
+	assert.Equal(t, []discord.Role{{ID: 1}}, roles)
+}
@@ -0,0 +1,628 @@
+package cache
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/flags"
+)
+
+// PresenceFields are used to configure which fields of a discord.Presence are kept by the compact presence cache.
+type PresenceFields int
+
+// values for PresenceFields
+const (
+	// PresenceFieldStatus keeps discord.Presence.Status.
+	PresenceFieldStatus PresenceFields = 1 << iota
+	// PresenceFieldClientStatus keeps discord.Presence.ClientStatus.
+	PresenceFieldClientStatus
+	// PresenceFieldActivities keeps the ID, Name, Type, ApplicationID & Flags of each discord.Activity.
+	PresenceFieldActivities
+	// PresenceFieldActivityDetails keeps the URL, Details, State, Emoji & Buttons of each discord.Activity.
+	PresenceFieldActivityDetails
+	// PresenceFieldActivityTimestamps keeps the CreatedAt & Timestamps of each discord.Activity.
+	PresenceFieldActivityTimestamps
+	// PresenceFieldActivityAssets keeps the Assets of each discord.Activity.
+	PresenceFieldActivityAssets
+	// PresenceFieldActivityParty keeps the Party, Secrets, SyncID & Instance of each discord.Activity.
+	PresenceFieldActivityParty
+
+	PresenceFieldsNone PresenceFields = 0
+	// PresenceFieldsDefault keeps the status and the basic activity information, which is enough for most bots.
+	PresenceFieldsDefault = PresenceFieldStatus |
+		PresenceFieldClientStatus |
+		PresenceFieldActivities
+	PresenceFieldsAll = PresenceFieldStatus |
+		PresenceFieldClientStatus |
+		PresenceFieldActivities |
+		PresenceFieldActivityDetails |
+		PresenceFieldActivityTimestamps |
+		PresenceFieldActivityAssets |
+		PresenceFieldActivityParty
+)
+
+// Add allows you to add multiple bits together, producing a new bit
+func (f PresenceFields) Add(bits ...PresenceFields) PresenceFields {
+	return flags.Add(f, bits...)
+}
+
+// Remove allows you to subtract multiple bits from the first, producing a new bit
+func (f PresenceFields) Remove(bits ...PresenceFields) PresenceFields {
+	return flags.Remove(f, bits...)
+}
+
+// Has will ensure that the bit includes all the bits entered
+func (f PresenceFields) Has(bits ...PresenceFields) bool {
+	return flags.Has(f, bits...)
+}
+
+// Missing will check whether the bit is missing any one of the bits
+func (f PresenceFields) Missing(bits ...PresenceFields) bool {
+	return flags.Missing(f, bits...)
+}
+
+var _ GroupedCache[discord.Presence] = (*compactPresenceCache)(nil)
+
+// NewCompactPresenceCache returns a PresenceCache which stores presences in a compact form.
+// See NewCompactPresenceGroupedCache for details.
+func NewCompactPresenceCache(flags Flags, policy Policy[discord.Presence], fields PresenceFields, feed ChangeFeed) PresenceCache {
+	return NewPresenceCache(NewCompactPresenceGroupedCache(flags, policy, fields, feed))
+}
+
+// NewCompactPresenceGroupedCache returns a GroupedCache for discord.Presence(s) which is optimized for memory usage in large guilds.
+// It only keeps the fields enabled in the given PresenceFields, shares equal activities between all presences
+// and interns all strings of these activities. Presences returned by this cache must be handled as immutable.
+func NewCompactPresenceGroupedCache(flags Flags, policy Policy[discord.Presence], fields PresenceFields, feed ChangeFeed) GroupedCache[discord.Presence] {
+	return &compactPresenceCache{
+		flags:      flags,
+		policy:     policy,
+		fields:     fields,
+		feed:       feed,
+		presences:  make(map[snowflake.ID]map[snowflake.ID]compactPresence),
+		activities: make(map[activityKey]*pooledActivity),
+		strings:    make(map[string]*pooledString),
+	}
+}
+
+type compactPresence struct {
+	status       discord.OnlineStatus
+	clientStatus discord.ClientStatus
+	activities   []*pooledActivity
+}
+
+type pooledActivity struct {
+	key      activityKey
+	activity discord.Activity
+	refs     int
+}
+
+type pooledString struct {
+	value string
+	refs  int
+}
+
+const (
+	activityKeyURL uint16 = 1 << iota
+	activityKeyDetails
+	activityKeyState
+	activityKeySyncID
+	activityKeyEmoji
+	activityKeyEmojiID
+	activityKeyEmojiName
+	activityKeyTimestamps
+	activityKeyParty
+	activityKeyAssets
+	activityKeySecrets
+	activityKeyInstance
+)
+
+// activityKey is the comparable representation of an already projected discord.Activity.
+type activityKey struct {
+	set           uint16
+	id            string
+	name          string
+	activityType  discord.ActivityType
+	applicationID snowflake.ID
+	flags         discord.ActivityFlags
+	url           string
+	details       string
+	state         string
+	syncID        string
+	emojiID       snowflake.ID
+	emojiName     string
+	emojiAnimated bool
+	buttons       string
+	createdAt     int64
+	start         int64
+	end           int64
+	party         discord.ActivityParty
+	assets        discord.ActivityAssets
+	secrets       discord.ActivitySecrets
+	instance      bool
+}
+
+type compactPresenceCache struct {
+	mu         sync.RWMutex
+	flags      Flags
+	policy     Policy[discord.Presence]
+	fields     PresenceFields
+	feed       ChangeFeed
+	seq        changeSequencer
+	presences  map[snowflake.ID]map[snowflake.ID]compactPresence
+	activities map[activityKey]*pooledActivity
+	strings    map[string]*pooledString
+}
+
+func (c *compactPresenceCache) Get(groupID snowflake.ID, id snowflake.ID) (discord.Presence, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if groupEntities, ok := c.presences[groupID]; ok {
+		if presence, ok := groupEntities[id]; ok {
+			return c.toPresence(groupID, id, presence), true
+		}
+	}
+	return discord.Presence{}, false
+}
+
+func (c *compactPresenceCache) Put(groupID snowflake.ID, id snowflake.ID, entity discord.Presence) {
+	if c.flags.Missing(FlagPresences) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	c.mu.Lock()
+	ticket, emit := c.seq.ticket(c.feed)
+	presence := c.toCompactPresence(entity)
+
+	var (
+		oldEntity discord.Presence
+		hadOld    bool
+	)
+	groupEntities, ok := c.presences[groupID]
+	if !ok {
+		groupEntities = make(map[snowflake.ID]compactPresence)
+		c.presences[groupID] = groupEntities
+	}
+	if oldPresence, ok := groupEntities[id]; ok {
+		if emit {
+			oldEntity, hadOld = c.toPresence(groupID, id, oldPresence), true
+		}
+		c.release(oldPresence)
+	}
+	groupEntities[id] = presence
+
+	var newEntity discord.Presence
+	if emit {
+		newEntity = c.toPresence(groupID, id, presence)
+	}
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	emitPut(c.feed, FlagPresences, groupID, id, oldEntity, hadOld, newEntity)
+}
+
+func (c *compactPresenceCache) Remove(groupID snowflake.ID, id snowflake.ID) (discord.Presence, bool) {
+	c.mu.Lock()
+	var (
+		entity discord.Presence
+		ok     bool
+	)
+	if groupEntities, gOk := c.presences[groupID]; gOk {
+		var presence compactPresence
+		if presence, ok = groupEntities[id]; ok {
+			entity = c.toPresence(groupID, id, presence)
+			delete(groupEntities, id)
+			c.release(presence)
+		}
+	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return entity, ok
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	if ok {
+		emitRemove(c.feed, FlagPresences, groupID, id, entity)
+	}
+	return entity, ok
+}
+
+func (c *compactPresenceCache) GroupRemove(groupID snowflake.ID) {
+	collect := c.feed != nil && c.feed.HasChangeListeners()
+	var removed []discord.Presence
+
+	c.mu.Lock()
+	for id, presence := range c.presences[groupID] {
+		if collect {
+			removed = append(removed, c.toPresence(groupID, id, presence))
+		}
+		c.release(presence)
+	}
+	delete(c.presences, groupID)
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	for _, entity := range removed {
+		emitRemove(c.feed, FlagPresences, groupID, entity.PresenceUser.ID, entity)
+	}
+}
+
+func (c *compactPresenceCache) RemoveIf(filterFunc GroupedFilterFunc[discord.Presence]) {
+	c.mu.RLock()
+	groupIDs := make([]snowflake.ID, 0, len(c.presences))
+	for groupID := range c.presences {
+		groupIDs = append(groupIDs, groupID)
+	}
+	c.mu.RUnlock()
+
+	for _, groupID := range groupIDs {
+		c.GroupRemoveIf(groupID, filterFunc)
+	}
+}
+
+func (c *compactPresenceCache) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[discord.Presence]) {
+	var removed []discord.Presence
+
+	c.mu.Lock()
+	for id, presence := range c.presences[groupID] {
+		entity := c.toPresence(groupID, id, presence)
+		if filterFunc(groupID, entity) {
+			delete(c.presences[groupID], id)
+			c.release(presence)
+			removed = append(removed, entity)
+		}
+	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	for _, entity := range removed {
+		emitRemove(c.feed, FlagPresences, groupID, entity.PresenceUser.ID, entity)
+	}
+}
+
+func (c *compactPresenceCache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var totalLen int
+	for _, groupEntities := range c.presences {
+		totalLen += len(groupEntities)
+	}
+	return totalLen
+}
+
+func (c *compactPresenceCache) GroupLen(groupID snowflake.ID) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.presences[groupID])
+}
+
+func (c *compactPresenceCache) ForEach(forEachFunc func(groupID snowflake.ID, entity discord.Presence)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for groupID, groupEntities := range c.presences {
+		for id, presence := range groupEntities {
+			forEachFunc(groupID, c.toPresence(groupID, id, presence))
+		}
+	}
+}
+
+func (c *compactPresenceCache) GroupForEach(groupID snowflake.ID, forEachFunc func(entity discord.Presence)) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for id, presence := range c.presences[groupID] {
+		forEachFunc(c.toPresence(groupID, id, presence))
+	}
+}
+
+func (c *compactPresenceCache) toPresence(groupID snowflake.ID, id snowflake.ID, presence compactPresence) discord.Presence {
+	entity := discord.Presence{
+		PresenceUser: discord.PresenceUser{ID: id},
+		GuildID:      groupID,
+		Status:       presence.status,
+		ClientStatus: presence.clientStatus,
+	}
+	if len(presence.activities) > 0 {
+		entity.Activities = make([]discord.Activity, len(presence.activities))
+		for i, activity := range presence.activities {
+			entity.Activities[i] = copyActivity(activity.activity)
+		}
+	}
+	return entity
+}
+
+// copyActivity returns a deep copy of the pooled activity, so callers can't modify the activity shared by other presences.
+func copyActivity(activity discord.Activity) discord.Activity {
+	activity.URL = clonePtr(activity.URL)
+	activity.Timestamps = clonePtr(activity.Timestamps)
+	activity.SyncID = clonePtr(activity.SyncID)
+	activity.Details = clonePtr(activity.Details)
+	activity.State = clonePtr(activity.State)
+	if activity.Emoji != nil {
+		emoji := *activity.Emoji
+		emoji.ID = clonePtr(emoji.ID)
+		emoji.Name = clonePtr(emoji.Name)
+		activity.Emoji = &emoji
+	}
+	activity.Party = clonePtr(activity.Party)
+	activity.Assets = clonePtr(activity.Assets)
+	activity.Secrets = clonePtr(activity.Secrets)
+	activity.Instance = clonePtr(activity.Instance)
+	activity.Buttons = slices.Clone(activity.Buttons)
+	return activity
+}
+
+func clonePtr[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+// toCompactPresence needs to be called with the write lock held as it acquires pooled activities.
+func (c *compactPresenceCache) toCompactPresence(entity discord.Presence) compactPresence {
+	var presence compactPresence
+	if c.fields.Has(PresenceFieldStatus) {
+		presence.status = canonicalOnlineStatus(entity.Status)
+	}
+	if c.fields.Has(PresenceFieldClientStatus) {
+		presence.clientStatus = discord.ClientStatus{
+			Desktop: canonicalOnlineStatus(entity.ClientStatus.Desktop),
+			Mobile:  canonicalOnlineStatus(entity.ClientStatus.Mobile),
+			Web:     canonicalOnlineStatus(entity.ClientStatus.Web),
+		}
+	}
+	if c.fields.Has(PresenceFieldActivities) && len(entity.Activities) > 0 {
+		presence.activities = make([]*pooledActivity, len(entity.Activities))
+		for i, activity := range entity.Activities {
+			presence.activities[i] = c.acquireActivity(c.activityKey(activity))
+		}
+	}
+	return presence
+}
+
+// release needs to be called with the write lock held.
+func (c *compactPresenceCache) release(presence compactPresence) {
+	for _, activity := range presence.activities {
+		activity.refs--
+		if activity.refs > 0 {
+			continue
+		}
+		delete(c.activities, activity.key)
+		k := activity.key
+		for _, s := range []string{k.id, k.name, k.url, k.details, k.state, k.syncID, k.emojiName, k.buttons,
+			k.party.ID, k.assets.LargeImage, k.assets.LargeText, k.assets.SmallImage, k.assets.SmallText,
+			k.secrets.Join, k.secrets.Spectate, k.secrets.Match} {
+			c.releaseString(s)
+		}
+	}
+}
+
+func (c *compactPresenceCache) activityKey(activity discord.Activity) activityKey {
+	key := activityKey{
+		id:            activity.ID,
+		name:          activity.Name,
+		activityType:  activity.Type,
+		applicationID: activity.ApplicationID,
+		flags:         activity.Flags,
+	}
+	if c.fields.Has(PresenceFieldActivityDetails) {
+		if activity.URL != nil {
+			key.set |= activityKeyURL
+			key.url = *activity.URL
+		}
+		if activity.Details != nil {
+			key.set |= activityKeyDetails
+			key.details = *activity.Details
+		}
+		if activity.State != nil {
+			key.set |= activityKeyState
+			key.state = *activity.State
+		}
+		if activity.Emoji != nil {
+			key.set |= activityKeyEmoji
+			key.emojiAnimated = activity.Emoji.Animated
+			if activity.Emoji.ID != nil {
+				key.set |= activityKeyEmojiID
+				key.emojiID = *activity.Emoji.ID
+			}
+			if activity.Emoji.Name != nil {
+				key.set |= activityKeyEmojiName
+				key.emojiName = *activity.Emoji.Name
+			}
+		}
+		key.buttons = strings.Join(activity.Buttons, "\x00")
+	}
+	if c.fields.Has(PresenceFieldActivityTimestamps) {
+		if !activity.CreatedAt.IsZero() {
+			key.createdAt = activity.CreatedAt.UnixMilli()
+		}
+		if activity.Timestamps != nil {
+			key.set |= activityKeyTimestamps
+			key.start = activity.Timestamps.Start.UnixMilli()
+			key.end = activity.Timestamps.End.UnixMilli()
+		}
+	}
+	if c.fields.Has(PresenceFieldActivityAssets) && activity.Assets != nil {
+		key.set |= activityKeyAssets
+		key.assets = *activity.Assets
+	}
+	if c.fields.Has(PresenceFieldActivityParty) {
+		if activity.Party != nil {
+			key.set |= activityKeyParty
+			key.party = *activity.Party
+		}
+		if activity.Secrets != nil {
+			key.set |= activityKeySecrets
+			key.secrets = *activity.Secrets
+		}
+		if activity.SyncID != nil {
+			key.set |= activityKeySyncID
+			key.syncID = *activity.SyncID
+		}
+		if activity.Instance != nil {
+			key.set |= activityKeyInstance
+			key.instance = *activity.Instance
+		}
+	}
+	return key
+}
+
+// acquireActivity needs to be called with the write lock held.
+func (c *compactPresenceCache) acquireActivity(key activityKey) *pooledActivity {
+	if activity, ok := c.activities[key]; ok {
+		activity.refs++
+		return activity
+	}
+
+	key.id = c.internString(key.id)
+	key.name = c.internString(key.name)
+	key.url = c.internString(key.url)
+	key.details = c.internString(key.details)
+	key.state = c.internString(key.state)
+	key.syncID = c.internString(key.syncID)
+	key.emojiName = c.internString(key.emojiName)
+	key.buttons = c.internString(key.buttons)
+	key.party.ID = c.internString(key.party.ID)
+	key.assets.LargeImage = c.internString(key.assets.LargeImage)
+	key.assets.LargeText = c.internString(key.assets.LargeText)
+	key.assets.SmallImage = c.internString(key.assets.SmallImage)
+	key.assets.SmallText = c.internString(key.assets.SmallText)
+	key.secrets.Join = c.internString(key.secrets.Join)
+	key.secrets.Spectate = c.internString(key.secrets.Spectate)
+	key.secrets.Match = c.internString(key.secrets.Match)
+
+	activity := &pooledActivity{
+		key:      key,
+		activity: key.toActivity(),
+		refs:     1,
+	}
+	c.activities[key] = activity
+	return activity
+}
+
+// internString needs to be called with the write lock held.
+func (c *compactPresenceCache) internString(s string) string {
+	if s == "" {
+		return ""
+	}
+	if pooled, ok := c.strings[s]; ok {
+		pooled.refs++
+		return pooled.value
+	}
+	c.strings[s] = &pooledString{value: s, refs: 1}
+	return s
+}
+
+// releaseString needs to be called with the write lock held.
+func (c *compactPresenceCache) releaseString(s string) {
+	if s == "" {
+		return
+	}
+	if pooled, ok := c.strings[s]; ok {
+		pooled.refs--
+		if pooled.refs <= 0 {
+			delete(c.strings, s)
+		}
+	}
+}
+
+func (k activityKey) toActivity() discord.Activity {
+	activity := discord.Activity{
+		ID:            k.id,
+		Name:          k.name,
+		Type:          k.activityType,
+		ApplicationID: k.applicationID,
+		Flags:         k.flags,
+	}
+	if k.set&activityKeyURL != 0 {
+		activity.URL = &k.url
+	}
+	if k.set&activityKeyDetails != 0 {
+		activity.Details = &k.details
+	}
+	if k.set&activityKeyState != 0 {
+		activity.State = &k.state
+	}
+	if k.set&activityKeySyncID != 0 {
+		activity.SyncID = &k.syncID
+	}
+	if k.set&activityKeyEmoji != 0 {
+		activity.Emoji = &discord.PartialEmoji{Animated: k.emojiAnimated}
+		if k.set&activityKeyEmojiID != 0 {
+			activity.Emoji.ID = &k.emojiID
+		}
+		if k.set&activityKeyEmojiName != 0 {
+			activity.Emoji.Name = &k.emojiName
+		}
+	}
+	if k.buttons != "" {
+		activity.Buttons = strings.Split(k.buttons, "\x00")
+	}
+	if k.createdAt != 0 {
+		activity.CreatedAt = time.UnixMilli(k.createdAt)
+	}
+	if k.set&activityKeyTimestamps != 0 {
+		activity.Timestamps = &discord.ActivityTimestamps{
+			Start: time.UnixMilli(k.start),
+			End:   time.UnixMilli(k.end),
+		}
+	}
+	if k.set&activityKeyParty != 0 {
+		activity.Party = &k.party
+	}
+	if k.set&activityKeyAssets != 0 {
+		activity.Assets = &k.assets
+	}
+	if k.set&activityKeySecrets != 0 {
+		activity.Secrets = &k.secrets
+	}
+	if k.set&activityKeyInstance != 0 {
+		activity.Instance = &k.instance
+	}
+	return activity
+}
+
+// canonicalOnlineStatus returns the constant for known discord.OnlineStatus(es), so decoded statuses don't keep their own copy.
+func canonicalOnlineStatus(status discord.OnlineStatus) discord.OnlineStatus {
+	switch status {
+	case discord.OnlineStatusOnline:
+		return discord.OnlineStatusOnline
+	case discord.OnlineStatusDND:
+		return discord.OnlineStatusDND
+	case discord.OnlineStatusIdle:
+		return discord.OnlineStatusIdle
+	case discord.OnlineStatusInvisible:
+		return discord.OnlineStatusInvisible
+	case discord.OnlineStatusOffline:
+		return discord.OnlineStatusOffline
+	default:
+		return status
+	}
+}
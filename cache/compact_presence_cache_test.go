@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCompactPresenceCache(t *testing.T) {
+	details := "some song"
+	c := NewCompactPresenceGroupedCache(FlagPresences, nil, PresenceFieldsDefault, nil).(*compactPresenceCache)
+
+	presence := func(userID snowflake.ID) discord.Presence {
+		return discord.Presence{
+			PresenceUser: discord.PresenceUser{ID: userID},
+			GuildID:      1,
+			Status:       discord.OnlineStatusOnline,
+			Activities: []discord.Activity{
+				{Name: "Spotify", Type: discord.ActivityTypeListening, Details: &details},
+			},
+		}
+	}
+
+	c.Put(1, 1001, presence(1001))
+	c.Put(1, 1002, presence(1002))
+
+	assert.Len(t, c.activities, 1, "equal projected activities should be shared")
+
+	p, ok := c.Get(1, 1001)
+	assert.True(t, ok)
+	assert.Equal(t, discord.OnlineStatusOnline, p.Status)
+	assert.Equal(t, "Spotify", p.Activities[0].Name)
+	assert.Nil(t, p.Activities[0].Details, "details are not part of PresenceFieldsDefault")
+
+	c.Remove(1, 1001)
+	assert.Len(t, c.activities, 1)
+
+	c.GroupRemove(1)
+	assert.Empty(t, c.activities)
+	assert.Empty(t, c.strings)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCompactPresenceCacheCopiesActivities(t *testing.T) {
+	details := "some song"
+	c := NewCompactPresenceGroupedCache(FlagPresences, nil, PresenceFieldsAll, nil)
+	for _, userID := range []snowflake.ID{1001, 1002} {
+		c.Put(1, userID, discord.Presence{
+			PresenceUser: discord.PresenceUser{ID: userID},
+			GuildID:      1,
+			Activities:   []discord.Activity{{Name: "Spotify", Details: &details, Assets: &discord.ActivityAssets{LargeText: "cover"}}},
+		})
+	}
+
+	p, _ := c.Get(1, 1001)
+	*p.Activities[0].Details = "changed"
+	p.Activities[0].Assets.LargeText = "changed"
+
+	p, _ = c.Get(1, 1002)
+	assert.Equal(t, "some song", *p.Activities[0].Details, "modifying a returned presence must not change pooled activities")
+	assert.Equal(t, "cover", p.Activities[0].Assets.LargeText)
+}
+
+func TestCompactPresenceCacheChangeOrder(t *testing.T) {
+	feed := NewChangeFeed()
+	c := NewCompactPresenceGroupedCache(FlagPresences, nil, PresenceFieldsDefault, feed)
+
+	// every update must continue where the previous one ended, even if Put is called concurrently
+	var (
+		mu   sync.Mutex
+		last discord.OnlineStatus
+		ok   = true
+	)
+	feed.AddChangeListener(NewTypedChangeListener(func(change Change, old discord.Presence, new discord.Presence) {
+		// give other mutations the chance to overtake this one
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		if old.Status != last {
+			ok = false
+		}
+		last = new.Status
+	}))
+
+	statuses := []discord.OnlineStatus{discord.OnlineStatusOnline, discord.OnlineStatusIdle, discord.OnlineStatusDND, discord.OnlineStatusOffline}
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(status discord.OnlineStatus) {
+			defer wg.Done()
+			c.Put(1, 1001, discord.Presence{PresenceUser: discord.PresenceUser{ID: 1001}, GuildID: 1, Status: status})
+		}(statuses[i%len(statuses)])
+	}
+	wg.Wait()
+
+	assert.True(t, ok, "changes should be emitted in the order they were applied")
+	p, _ := c.Get(1, 1001)
+	assert.Equal(t, p.Status, last)
+}
@@ -47,22 +47,47 @@ var _ GroupedCache[any] = (*defaultGroupedCache[any])(nil)
 
 // NewGroupedCache returns a new default GroupedCache with the provided flags, neededFlags and policy.
 func NewGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T]) GroupedCache[T] {
+	return NewGroupedCacheWithChangeFeed(flags, neededFlags, policy, nil)
+}
+
+// NewGroupedCacheWithChangeFeed returns a new default GroupedCache like NewGroupedCache which additionally reports every mutation to the given ChangeFeed.
+// The neededFlags are used as Change.Cache.
+func NewGroupedCacheWithChangeFeed[T any](flags Flags, neededFlags Flags, policy Policy[T], feed ChangeFeed) GroupedCache[T] {
 	return &defaultGroupedCache[T]{
 		flags:       flags,
 		neededFlags: neededFlags,
 		policy:      policy,
+		feed:        feed,
 		cache:       make(map[snowflake.ID]map[snowflake.ID]T),
 	}
 }
 
 type defaultGroupedCache[T any] struct {
 	mu          sync.RWMutex
+	seq         changeSequencer
 	flags       Flags
 	neededFlags Flags
 	policy      Policy[T]
+	feed        ChangeFeed
 	cache       map[snowflake.ID]map[snowflake.ID]T
 }
 
+type groupedEntity[T any] struct {
+	groupID snowflake.ID
+	id      snowflake.ID
+	entity  T
+}
+
+func (c *defaultGroupedCache[T]) collectRemoved() bool {
+	return c.feed != nil && c.feed.HasChangeListeners()
+}
+
+func (c *defaultGroupedCache[T]) emitRemoved(removed []groupedEntity[T]) {
+	for _, r := range removed {
+		emitRemove(c.feed, c.neededFlags, r.groupID, r.id, r.entity)
+	}
+}
+
 func (c *defaultGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -85,66 +110,126 @@ func (c *defaultGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, enti
 		return
 	}
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
 	if c.cache == nil {
 		c.cache = make(map[snowflake.ID]map[snowflake.ID]T)
 	}
 
+	var (
+		oldEntity T
+		hadOld    bool
+	)
 	if groupEntities, ok := c.cache[groupID]; ok {
+		oldEntity, hadOld = groupEntities[id]
 		groupEntities[id] = entity
 	} else {
 		groupEntities = make(map[snowflake.ID]T)
 		groupEntities[id] = entity
 		c.cache[groupID] = groupEntities
 	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	emitPut(c.feed, c.neededFlags, groupID, id, oldEntity, hadOld, entity)
 }
 
 func (c *defaultGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (entity T, ok bool) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if groupEntities, ok := c.cache[groupID]; ok {
-		if entity, ok := groupEntities[id]; ok {
+	if groupEntities, gOk := c.cache[groupID]; gOk {
+		if entity, ok = groupEntities[id]; ok {
 			delete(groupEntities, id)
-			return entity, ok
 		}
 	}
-	ok = false
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	if ok {
+		emitRemove(c.feed, c.neededFlags, groupID, id, entity)
+	}
 	return
 }
 
 func (c *defaultGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	collect := c.collectRemoved()
+	var removed []groupedEntity[T]
 
+	c.mu.Lock()
+	if collect {
+		for id, entity := range c.cache[groupID] {
+			removed = append(removed, groupedEntity[T]{groupID: groupID, id: id, entity: entity})
+		}
+	}
 	delete(c.cache, groupID)
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	c.emitRemoved(removed)
 }
 
 func (c *defaultGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	collect := c.collectRemoved()
+	var removed []groupedEntity[T]
 
+	c.mu.Lock()
 	for groupID := range c.cache {
 		for id, entity := range c.cache[groupID] {
 			if filterFunc(groupID, entity) {
 				delete(c.cache[groupID], id)
+				if collect {
+					removed = append(removed, groupedEntity[T]{groupID: groupID, id: id, entity: entity})
+				}
 			}
 		}
 	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	c.emitRemoved(removed)
 }
 
 func (c *defaultGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	collect := c.collectRemoved()
+	var removed []groupedEntity[T]
 
+	c.mu.Lock()
 	if groupEntities, ok := c.cache[groupID]; ok {
 		for id, entity := range groupEntities {
 			if filterFunc(groupID, entity) {
 				delete(c.cache[groupID], id)
+				if collect {
+					removed = append(removed, groupedEntity[T]{groupID: groupID, id: id, entity: entity})
+				}
 			}
 		}
 	}
+	ticket, emit := c.seq.ticket(c.feed)
+	c.mu.Unlock()
+	if !emit {
+		return
+	}
+	c.seq.wait(ticket)
+	defer c.seq.done()
+
+	c.emitRemoved(removed)
 }
 
 func (c *defaultGroupedCache[T]) Len() int {
@@ -0,0 +1,46 @@
+package cache
+
+// ModerationReason describes why a Member is not allowed to act on another Member or Role.
+type ModerationReason int
+
+// All ModerationReason(s) returned by Caches.CanModerate and Caches.CanManageRole.
+const (
+	// ModerationReasonNone is returned when the action is allowed.
+	ModerationReasonNone ModerationReason = iota
+	// ModerationReasonGuildNotCached is returned when the guild is missing from the GuildCache and the ownership can't be checked.
+	ModerationReasonGuildNotCached
+	// ModerationReasonDifferentGuild is returned when the actor and the target are not part of the same guild.
+	ModerationReasonDifferentGuild
+	// ModerationReasonSelf is returned when the actor and the target are the same Member.
+	ModerationReasonSelf
+	// ModerationReasonTargetOwner is returned when the target is the owner of the guild.
+	ModerationReasonTargetOwner
+	// ModerationReasonMissingPermissions is returned when the actor is missing the required discord.Permissions.
+	ModerationReasonMissingPermissions
+	// ModerationReasonRoleHierarchy is returned when the highest role of the actor is not higher than the highest role of the target or the target role.
+	ModerationReasonRoleHierarchy
+	// ModerationReasonManagedRole is returned when the target role is managed by an integration and can't be assigned by anyone.
+	ModerationReasonManagedRole
+)
+
+func (r ModerationReason) String() string {
+	switch r {
+	case ModerationReasonNone:
+		return "none"
+	case ModerationReasonGuildNotCached:
+		return "guild not cached"
+	case ModerationReasonDifferentGuild:
+		return "different guild"
+	case ModerationReasonSelf:
+		return "self"
+	case ModerationReasonTargetOwner:
+		return "target is guild owner"
+	case ModerationReasonMissingPermissions:
+		return "missing permissions"
+	case ModerationReasonRoleHierarchy:
+		return "role hierarchy"
+	case ModerationReasonManagedRole:
+		return "managed role"
+	}
+	return "unknown"
+}
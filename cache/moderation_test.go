@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCanModerate(t *testing.T) {
+	const (
+		guildID   snowflake.ID = 1
+		ownerID   snowflake.ID = 10
+		modRoleID snowflake.ID = 2
+		adminRole snowflake.ID = 3
+	)
+	caches := New(WithCaches(FlagGuilds | FlagRoles))
+	caches.AddGuild(discord.Guild{ID: guildID, OwnerID: ownerID})
+	caches.AddRole(discord.Role{ID: guildID, GuildID: guildID})
+	caches.AddRole(discord.Role{ID: modRoleID, GuildID: guildID, Position: 1, Permissions: discord.PermissionKickMembers | discord.PermissionManageRoles})
+	caches.AddRole(discord.Role{ID: adminRole, GuildID: guildID, Position: 2, Permissions: discord.PermissionAdministrator})
+
+	member := func(userID snowflake.ID, roleIDs ...snowflake.ID) discord.Member {
+		return discord.Member{User: discord.User{ID: userID}, GuildID: guildID, RoleIDs: roleIDs}
+	}
+	owner := member(ownerID)
+	admin := member(11, adminRole)
+	mod := member(12, modRoleID)
+	user := member(13)
+
+	highestRole, ok := caches.HighestRole(admin)
+	assert.True(t, ok)
+	assert.Equal(t, adminRole, highestRole.ID)
+
+	highestRole, ok = caches.HighestRole(user)
+	assert.True(t, ok)
+	assert.Equal(t, guildID, highestRole.ID, "members without roles should return the @everyone role")
+
+	canModerate := func(actor discord.Member, target discord.Member) ModerationReason {
+		_, reason := caches.CanModerate(actor, target, discord.PermissionKickMembers)
+		return reason
+	}
+	assert.Equal(t, ModerationReasonNone, canModerate(owner, admin))
+	assert.Equal(t, ModerationReasonNone, canModerate(admin, mod))
+	assert.Equal(t, ModerationReasonNone, canModerate(mod, user))
+	assert.Equal(t, ModerationReasonTargetOwner, canModerate(admin, owner))
+	assert.Equal(t, ModerationReasonRoleHierarchy, canModerate(mod, admin))
+	assert.Equal(t, ModerationReasonRoleHierarchy, canModerate(mod, member(14, modRoleID)))
+	assert.Equal(t, ModerationReasonMissingPermissions, canModerate(user, member(14)))
+	assert.Equal(t, ModerationReasonSelf, canModerate(mod, mod))
+
+	modRole, _ := caches.Role(guildID, modRoleID)
+	_, reason := caches.CanManageRole(mod, modRole)
+	assert.Equal(t, ModerationReasonRoleHierarchy, reason)
+
+	ok, reason = caches.CanManageRole(admin, modRole)
+	assert.True(t, ok)
+	assert.Equal(t, ModerationReasonNone, reason)
+
+	_, reason = caches.CanManageRole(owner, discord.Role{ID: 4, GuildID: guildID, Managed: true})
+	assert.Equal(t, ModerationReasonManagedRole, reason)
+}
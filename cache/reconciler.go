@@ -0,0 +1,345 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ Reconciler = (*reconcilerImpl)(nil)
+
+// ReconcileResult contains the differences found for a single entity cache.
+type ReconcileResult struct {
+	// Added are the IDs of entities which were missing from the cache.
+	Added []snowflake.ID
+	// Updated are the IDs of entities which were outdated in the cache.
+	Updated []snowflake.ID
+	// Removed are the IDs of entities which were still cached but no longer exist.
+	Removed []snowflake.ID
+}
+
+// Changed returns whether any difference was found.
+func (r ReconcileResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Updated) > 0 || len(r.Removed) > 0
+}
+
+// ReconcileReport is the result of reconciling the caches of a single guild.
+type ReconcileReport struct {
+	GuildID snowflake.ID
+	// DryRun is true if the differences were only reported and not fixed.
+	DryRun    bool
+	StartedAt time.Time
+	Duration  time.Duration
+
+	Channels ReconcileResult
+	Roles    ReconcileResult
+	Emojis   ReconcileResult
+	Stickers ReconcileResult
+	Members  ReconcileResult
+
+	// Err contains all errors which occurred while fetching the entities. Entity caches which failed to fetch are left untouched.
+	Err error
+}
+
+// Changed returns whether any difference was found.
+func (r ReconcileReport) Changed() bool {
+	return r.Channels.Changed() || r.Roles.Changed() || r.Emojis.Changed() || r.Stickers.Changed() || r.Members.Changed()
+}
+
+// Reconciler compares the Caches with the current state fetched from the rest.Rest API and fixes the differences.
+// This is useful after events were missed, for example because a gateway.Gateway failed to resume.
+type Reconciler interface {
+	// Reconcile reconciles the caches of the given guild and returns a ReconcileReport.
+	// The returned error is the same as ReconcileReport.Err.
+	Reconcile(ctx context.Context, guildID snowflake.ID) (ReconcileReport, error)
+
+	// ReconcileAll reconciles the caches of all guilds in the GuildCache one after another.
+	ReconcileAll(ctx context.Context) ([]ReconcileReport, error)
+
+	// Run calls ReconcileAll every interval until the context.Context is cancelled.
+	Run(ctx context.Context, interval time.Duration)
+}
+
+// NewReconciler returns a new Reconciler for the given Caches and rest.Rest with the ReconcilerConfigOpt(s) applied.
+func NewReconciler(caches Caches, restClient rest.Rest, opts ...ReconcilerConfigOpt) Reconciler {
+	cfg := DefaultReconcilerConfig()
+	cfg.Apply(opts)
+	cfg.Logger = cfg.Logger.With(slog.String("name", "cache_reconciler"))
+
+	return &reconcilerImpl{
+		caches: caches,
+		rest:   restClient,
+		config: *cfg,
+	}
+}
+
+type reconcilerImpl struct {
+	caches Caches
+	rest   rest.Rest
+	config ReconcilerConfig
+}
+
+func (r *reconcilerImpl) enabled(flag Flags) bool {
+	return r.config.Flags.Has(flag) && r.caches.CacheFlags().Has(flag)
+}
+
+func (r *reconcilerImpl) Reconcile(ctx context.Context, guildID snowflake.ID) (ReconcileReport, error) {
+	report := ReconcileReport{
+		GuildID:   guildID,
+		DryRun:    r.config.DryRun,
+		StartedAt: time.Now(),
+	}
+	var errs []error
+
+	if r.enabled(FlagChannels) {
+		fetchedAt := time.Now()
+		if channels, err := r.rest.GetGuildChannels(guildID, rest.WithCtx(ctx)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch channels: %w", err))
+		} else {
+			report.Channels = r.reconcileChannels(guildID, fetchedAt, channels)
+		}
+	}
+
+	if r.enabled(FlagRoles) {
+		fetchedAt := time.Now()
+		if roles, err := r.rest.GetRoles(guildID, rest.WithCtx(ctx)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch roles: %w", err))
+		} else {
+			report.Roles = reconcile(r.config.DryRun, fetchedAt, roles,
+				func(role discord.Role) snowflake.ID { return role.ID },
+				func(role discord.Role) time.Time { return role.ID.Time() },
+				func(fn func(role discord.Role)) { r.caches.RolesForEach(guildID, fn) },
+				func(id snowflake.ID) (discord.Role, bool) { return r.caches.Role(guildID, id) },
+				r.caches.AddRole,
+				func(id snowflake.ID) { r.caches.RemoveRole(guildID, id) },
+				func(a discord.Role, b discord.Role) bool { return len(DiffRole(a, b)) == 0 },
+			)
+		}
+	}
+
+	if r.enabled(FlagEmojis) {
+		fetchedAt := time.Now()
+		if emojis, err := r.rest.GetEmojis(guildID, rest.WithCtx(ctx)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch emojis: %w", err))
+		} else {
+			report.Emojis = reconcile(r.config.DryRun, fetchedAt, emojis,
+				func(emoji discord.Emoji) snowflake.ID { return emoji.ID },
+				func(emoji discord.Emoji) time.Time { return emoji.ID.Time() },
+				func(fn func(emoji discord.Emoji)) { r.caches.EmojisForEach(guildID, fn) },
+				func(id snowflake.ID) (discord.Emoji, bool) { return r.caches.Emoji(guildID, id) },
+				r.caches.AddEmoji,
+				func(id snowflake.ID) { r.caches.RemoveEmoji(guildID, id) },
+				equalEmoji,
+			)
+		}
+	}
+
+	if r.enabled(FlagStickers) {
+		fetchedAt := time.Now()
+		if stickers, err := r.rest.GetStickers(guildID, rest.WithCtx(ctx)); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch stickers: %w", err))
+		} else {
+			for i := range stickers {
+				if stickers[i].GuildID == nil {
+					stickers[i].GuildID = &guildID
+				}
+			}
+			report.Stickers = reconcile(r.config.DryRun, fetchedAt, stickers,
+				func(sticker discord.Sticker) snowflake.ID { return sticker.ID },
+				func(sticker discord.Sticker) time.Time { return sticker.ID.Time() },
+				func(fn func(sticker discord.Sticker)) { r.caches.StickersForEach(guildID, fn) },
+				func(id snowflake.ID) (discord.Sticker, bool) { return r.caches.Sticker(guildID, id) },
+				r.caches.AddSticker,
+				func(id snowflake.ID) { r.caches.RemoveSticker(guildID, id) },
+				equalSticker,
+			)
+		}
+	}
+
+	if r.enabled(FlagMembers) {
+		fetchedAt := time.Now()
+		if members, err := r.fetchMembers(ctx, guildID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch members: %w", err))
+		} else {
+			report.Members = reconcile(r.config.DryRun, fetchedAt, members,
+				func(member discord.Member) snowflake.ID { return member.User.ID },
+				// members keep the id of their user, but rejoining members have a new join time
+				func(member discord.Member) time.Time { return member.JoinedAt },
+				func(fn func(member discord.Member)) { r.caches.MembersForEach(guildID, fn) },
+				func(id snowflake.ID) (discord.Member, bool) { return r.caches.Member(guildID, id) },
+				r.caches.AddMember,
+				func(id snowflake.ID) { r.caches.RemoveMember(guildID, id) },
+				func(a discord.Member, b discord.Member) bool { return len(DiffMember(a, b)) == 0 },
+			)
+		}
+	}
+
+	report.Duration = time.Since(report.StartedAt)
+	report.Err = errors.Join(errs...)
+
+	if report.Changed() {
+		r.config.Logger.Debug("reconciled guild caches",
+			slog.Any("guild_id", guildID),
+			slog.Bool("dry_run", report.DryRun),
+			slog.Duration("duration", report.Duration),
+		)
+	}
+	if r.config.ReportFunc != nil {
+		r.config.ReportFunc(report)
+	}
+	return report, report.Err
+}
+
+func (r *reconcilerImpl) ReconcileAll(ctx context.Context) ([]ReconcileReport, error) {
+	var guildIDs []snowflake.ID
+	r.caches.GuildsForEach(func(guild discord.Guild) {
+		guildIDs = append(guildIDs, guild.ID)
+	})
+
+	var (
+		reports []ReconcileReport
+		errs    []error
+	)
+	for _, guildID := range guildIDs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		report, err := r.Reconcile(ctx, guildID)
+		reports = append(reports, report)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile guild %s: %w", guildID, err))
+		}
+	}
+	return reports, errors.Join(errs...)
+}
+
+func (r *reconcilerImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.ReconcileAll(ctx); err != nil {
+				r.config.Logger.Error("failed to reconcile caches", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+func (r *reconcilerImpl) reconcileChannels(guildID snowflake.ID, fetchedAt time.Time, channels []discord.GuildChannel) ReconcileResult {
+	// threads are not returned by rest.Guilds.GetGuildChannels, so we leave them untouched
+	return reconcile(r.config.DryRun, fetchedAt, channels,
+		func(channel discord.GuildChannel) snowflake.ID { return channel.ID() },
+		func(channel discord.GuildChannel) time.Time { return channel.ID().Time() },
+		func(fn func(channel discord.GuildChannel)) {
+			r.caches.ChannelsForEach(func(channel discord.GuildChannel) {
+				if _, ok := channel.(discord.GuildThread); ok || channel.GuildID() != guildID {
+					return
+				}
+				fn(channel)
+			})
+		},
+		r.caches.Channel,
+		r.caches.AddChannel,
+		func(id snowflake.ID) { r.caches.RemoveChannel(id) },
+		func(a discord.GuildChannel, b discord.GuildChannel) bool { return len(DiffGuildChannel(a, b)) == 0 },
+	)
+}
+
+func (r *reconcilerImpl) fetchMembers(ctx context.Context, guildID snowflake.ID) ([]discord.Member, error) {
+	var (
+		members []discord.Member
+		after   snowflake.ID
+	)
+	for {
+		page, err := r.rest.GetMembers(guildID, r.config.MemberPageSize, after, rest.WithCtx(ctx))
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		if len(page) == 0 || len(page) < r.config.MemberPageSize {
+			return members, nil
+		}
+		after = page[len(page)-1].User.ID
+	}
+}
+
+// reconcileClockSkew is subtracted from the time the fetch started before comparing it with the creation time of cached entities,
+// as the snowflakes of Discord and the local clock can differ slightly.
+const reconcileClockSkew = 5 * time.Second
+
+// reconcile compares the fetched entities with the cached ones and fixes the differences unless dryRun is set.
+// Entities which are rejected by the cache (e.g. because of a Policy) are not reported as added.
+// Cached entities which are missing from the fetched ones are only removed if they were created before the fetch started,
+// as entities created by gateway events during a long fetch are newer than the fetched ones.
+func reconcile[T any](dryRun bool, fetchedAt time.Time, fetched []T, idFunc func(T) snowflake.ID, createdAtFunc func(T) time.Time, forEachFunc func(fn func(T)), getFunc func(snowflake.ID) (T, bool), putFunc func(T), removeFunc func(snowflake.ID), equalFunc func(T, T) bool) ReconcileResult {
+	var result ReconcileResult
+
+	fetchedIDs := make(map[snowflake.ID]struct{}, len(fetched))
+	for _, entity := range fetched {
+		id := idFunc(entity)
+		fetchedIDs[id] = struct{}{}
+
+		cached, ok := getFunc(id)
+		if ok && equalFunc(cached, entity) {
+			continue
+		}
+		if !dryRun {
+			putFunc(entity)
+			if _, stored := getFunc(id); !stored {
+				continue
+			}
+		}
+		if ok {
+			result.Updated = append(result.Updated, id)
+		} else {
+			result.Added = append(result.Added, id)
+		}
+	}
+
+	fetchedAt = fetchedAt.Add(-reconcileClockSkew)
+	forEachFunc(func(entity T) {
+		if _, ok := fetchedIDs[idFunc(entity)]; !ok && createdAtFunc(entity).Before(fetchedAt) {
+			result.Removed = append(result.Removed, idFunc(entity))
+		}
+	})
+	if !dryRun {
+		for _, id := range result.Removed {
+			removeFunc(id)
+		}
+	}
+	return result
+}
+
+func equalEmoji(a discord.Emoji, b discord.Emoji) bool {
+	if a.Name != b.Name || a.RequireColons != b.RequireColons || a.Managed != b.Managed || a.Animated != b.Animated || a.Available != b.Available {
+		return false
+	}
+	added, removed := DiffIDs(a.Roles, b.Roles)
+	return len(added) == 0 && len(removed) == 0
+}
+
+func equalSticker(a discord.Sticker, b discord.Sticker) bool {
+	if a.Name != b.Name || a.Description != b.Description || a.Tags != b.Tags || a.Type != b.Type || a.FormatType != b.FormatType {
+		return false
+	}
+	return equalPtr(a.Available, b.Available)
+}
+
+func equalPtr[T comparable](a *T, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
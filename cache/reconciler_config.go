@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"log/slog"
+)
+
+// DefaultReconcilerConfig returns a ReconcilerConfig with sensible defaults.
+func DefaultReconcilerConfig() *ReconcilerConfig {
+	return &ReconcilerConfig{
+		Logger:         slog.Default(),
+		Flags:          FlagChannels | FlagRoles | FlagEmojis | FlagStickers | FlagMembers,
+		MemberPageSize: 1000,
+	}
+}
+
+// ReconcilerConfig lets you configure your Reconciler instance.
+type ReconcilerConfig struct {
+	Logger *slog.Logger
+
+	// Flags are the entity caches which get reconciled. Only FlagChannels, FlagRoles, FlagEmojis, FlagStickers & FlagMembers are supported.
+	// Caches which are not enabled in Caches.CacheFlags are always skipped.
+	Flags Flags
+
+	// DryRun only reports the differences without fixing them.
+	DryRun bool
+
+	// MemberPageSize is the limit used for each rest.Members.GetMembers request. It is clamped to 1-1000.
+	MemberPageSize int
+
+	// ReportFunc is called with each ReconcileReport after a guild was reconciled.
+	ReportFunc func(report ReconcileReport)
+}
+
+// ReconcilerConfigOpt is a type alias for a function that takes a ReconcilerConfig and is used to configure your Reconciler.
+type ReconcilerConfigOpt func(config *ReconcilerConfig)
+
+// Apply applies the given ReconcilerConfigOpt(s) to the ReconcilerConfig
+func (c *ReconcilerConfig) Apply(opts []ReconcilerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	// Discord allows at most 1000 members per request
+	c.MemberPageSize = min(max(c.MemberPageSize, 1), 1000)
+}
+
+// WithReconcilerLogger sets the Logger of the ReconcilerConfig.
+func WithReconcilerLogger(logger *slog.Logger) ReconcilerConfigOpt {
+	return func(config *ReconcilerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithReconcilerFlags sets which entity caches get reconciled.
+func WithReconcilerFlags(flags ...Flags) ReconcilerConfigOpt {
+	return func(config *ReconcilerConfig) {
+		config.Flags = FlagsNone.Add(flags...)
+	}
+}
+
+// WithReconcilerDryRun lets the Reconciler only report differences without fixing them.
+func WithReconcilerDryRun() ReconcilerConfigOpt {
+	return func(config *ReconcilerConfig) {
+		config.DryRun = true
+	}
+}
+
+// WithReconcilerMemberPageSize sets the limit used for each rest.Members.GetMembers request. It is clamped to 1-1000.
+func WithReconcilerMemberPageSize(pageSize int) ReconcilerConfigOpt {
+	return func(config *ReconcilerConfig) {
+		config.MemberPageSize = pageSize
+	}
+}
+
+// WithReconcilerReportFunc sets the func which is called with each ReconcileReport.
+func WithReconcilerReportFunc(reportFunc func(report ReconcileReport)) ReconcilerConfigOpt {
+	return func(config *ReconcilerConfig) {
+		config.ReportFunc = reportFunc
+	}
+}
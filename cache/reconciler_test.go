@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var _ rest.Rest = (*reconcilerRest)(nil)
+
+// reconcilerRest serves the roles and members of a single guild. All other requests panic.
+type reconcilerRest struct {
+	rest.Rest
+	roles      []discord.Role
+	members    []discord.Member
+	memberErr  error
+	pageLimits []int
+	// onGetMembers is called for each page, for example to simulate gateway events during the fetch.
+	onGetMembers func()
+}
+
+func (r *reconcilerRest) GetRoles(_ snowflake.ID, _ ...rest.RequestOpt) ([]discord.Role, error) {
+	return r.roles, nil
+}
+
+func (r *reconcilerRest) GetMembers(_ snowflake.ID, limit int, after snowflake.ID, _ ...rest.RequestOpt) ([]discord.Member, error) {
+	r.pageLimits = append(r.pageLimits, limit)
+	if r.onGetMembers != nil {
+		r.onGetMembers()
+	}
+	if r.memberErr != nil {
+		return nil, r.memberErr
+	}
+	var page []discord.Member
+	for _, member := range r.members {
+		if member.User.ID > after && len(page) < limit {
+			page = append(page, member)
+		}
+	}
+	return page, nil
+}
+
+func TestReconciler(t *testing.T) {
+	const guildID snowflake.ID = 1
+	member := func(userID snowflake.ID, roleIDs ...snowflake.ID) discord.Member {
+		return discord.Member{User: discord.User{ID: userID}, GuildID: guildID, RoleIDs: roleIDs}
+	}
+
+	caches := New(WithCaches(FlagGuilds | FlagRoles | FlagMembers))
+	caches.AddGuild(discord.Guild{ID: guildID})
+	caches.AddRole(discord.Role{ID: 10, GuildID: guildID, Name: "unchanged"})
+	caches.AddRole(discord.Role{ID: 11, GuildID: guildID, Name: "old"})
+	caches.AddRole(discord.Role{ID: 12, GuildID: guildID, Name: "deleted"})
+	caches.AddMember(member(100))
+	caches.AddMember(member(101))
+
+	restClient := &reconcilerRest{
+		roles: []discord.Role{
+			{ID: 10, GuildID: guildID, Name: "unchanged"},
+			{ID: 11, GuildID: guildID, Name: "new"},
+			{ID: 13, GuildID: guildID, Name: "created"},
+		},
+		members: []discord.Member{member(100, 10), member(102), member(103), member(104), member(105)},
+	}
+
+	var reports []ReconcileReport
+	reconciler := NewReconciler(caches, restClient,
+		WithReconcilerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithReconcilerMemberPageSize(2),
+		WithReconcilerReportFunc(func(report ReconcileReport) {
+			reports = append(reports, report)
+		}),
+	)
+
+	report, err := reconciler.Reconcile(context.Background(), guildID)
+	require.NoError(t, err)
+	assert.Equal(t, ReconcileResult{Added: []snowflake.ID{13}, Updated: []snowflake.ID{11}, Removed: []snowflake.ID{12}}, report.Roles)
+	assert.Equal(t, ReconcileResult{Added: []snowflake.ID{102, 103, 104, 105}, Updated: []snowflake.ID{100}, Removed: []snowflake.ID{101}}, report.Members)
+	assert.Equal(t, []int{2, 2, 2}, restClient.pageLimits, "members should be fetched in pages")
+	assert.Len(t, reports, 1)
+
+	role, ok := caches.Role(guildID, 11)
+	require.True(t, ok)
+	assert.Equal(t, "new", role.Name)
+	_, ok = caches.Role(guildID, 12)
+	assert.False(t, ok)
+	assert.Equal(t, 5, caches.MembersLen(guildID))
+
+	report, err = reconciler.Reconcile(context.Background(), guildID)
+	require.NoError(t, err)
+	assert.False(t, report.Changed(), "reconciled caches should not change again")
+}
+
+func TestReconcilerDryRunAndErrors(t *testing.T) {
+	const guildID snowflake.ID = 1
+	caches := New(WithCaches(FlagGuilds | FlagRoles | FlagMembers))
+	caches.AddRole(discord.Role{ID: 10, GuildID: guildID})
+	caches.AddMember(discord.Member{User: discord.User{ID: 100}, GuildID: guildID})
+
+	restClient := &reconcilerRest{memberErr: errors.New("boom")}
+	reconciler := NewReconciler(caches, restClient,
+		WithReconcilerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithReconcilerDryRun(),
+		WithReconcilerMemberPageSize(0),
+	)
+
+	report, err := reconciler.Reconcile(context.Background(), guildID)
+	assert.ErrorContains(t, err, "failed to fetch members: boom")
+	assert.Equal(t, []snowflake.ID{10}, report.Roles.Removed)
+	assert.False(t, report.Members.Changed(), "members which failed to fetch should be left untouched")
+	assert.Equal(t, []int{1}, restClient.pageLimits, "the page size should be clamped")
+
+	_, ok := caches.Role(guildID, 10)
+	assert.True(t, ok, "dry runs should not change the caches")
+}
+
+func TestReconcilerKeepsNewerEntities(t *testing.T) {
+	const guildID snowflake.ID = 1
+	caches := New(WithCaches(FlagGuilds | FlagRoles | FlagMembers))
+	caches.AddGuild(discord.Guild{ID: guildID})
+	newRoleID := snowflake.New(time.Now())
+	caches.AddRole(discord.Role{ID: newRoleID, GuildID: guildID, Name: "created after the fetch"})
+
+	restClient := &reconcilerRest{
+		members: []discord.Member{{User: discord.User{ID: 100}, GuildID: guildID}},
+		onGetMembers: func() {
+			// an old user joins while the members are fetched
+			caches.AddMember(discord.Member{User: discord.User{ID: 101}, GuildID: guildID, JoinedAt: time.Now()})
+		},
+	}
+	reconciler := NewReconciler(caches, restClient, WithReconcilerLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+
+	report, err := reconciler.Reconcile(context.Background(), guildID)
+	require.NoError(t, err)
+	assert.Empty(t, report.Roles.Removed, "roles created after the fetch started should be kept")
+	assert.Empty(t, report.Members.Removed, "members who joined after the fetch started should be kept")
+	_, ok := caches.Member(guildID, 101)
+	assert.True(t, ok)
+	_, ok = caches.Role(guildID, newRoleID)
+	assert.True(t, ok)
+}
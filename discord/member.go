@@ -99,6 +99,11 @@ func (m Member) CreatedAt() time.Time {
 	return m.User.CreatedAt()
 }
 
+// IsCommunicationDisabled returns whether the Member is currently timed out.
+func (m Member) IsCommunicationDisabled() bool {
+	return m.CommunicationDisabledUntil != nil && m.CommunicationDisabledUntil.After(time.Now())
+}
+
 // MemberAdd is used to add a member via the oauth2 access token to a guild
 type MemberAdd struct {
 	AccessToken string         `json:"access_token"`
@@ -0,0 +1,125 @@
+package discord
+
+import (
+	"slices"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// PermissionsTimedOut are the only Permissions a Member keeps while it is timed out.
+// Administrators and the guild owner are not affected by timeouts.
+const PermissionsTimedOut = PermissionViewChannel | PermissionReadMessageHistory
+
+// PermissionsRequiringSendMessages are implicitly denied when a Member is missing PermissionSendMessages in a channel.
+const PermissionsRequiringSendMessages = PermissionMentionEveryone |
+	PermissionSendTTSMessages |
+	PermissionAttachFiles |
+	PermissionEmbedLinks
+
+// ComputeBasePermissions computes the guild wide Permissions of the given Member following Discord's algorithm (https://discord.com/developers/docs/topics/permissions#permission-overwrites).
+// The roles need to contain at least the @everyone Role and all roles of the Member, additional roles are ignored.
+// Member.GuildID needs to be set. Timeouts are not applied, see Member.IsCommunicationDisabled.
+func ComputeBasePermissions(ownerID snowflake.ID, member Member, roles []Role) Permissions {
+	if member.User.ID == ownerID {
+		return PermissionsAll
+	}
+
+	var permissions Permissions
+	for _, role := range roles {
+		if role.ID == member.GuildID || slices.Contains(member.RoleIDs, role.ID) {
+			permissions = permissions.Add(role.Permissions)
+		}
+	}
+	if permissions.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+	return permissions
+}
+
+// ComputeOverwrites applies the PermissionOverwrites of a channel to the base Permissions of the given Member.
+// The @everyone overwrite is applied first, then all role overwrites of the Member combined and lastly the Member overwrite.
+func ComputeOverwrites(basePermissions Permissions, guildID snowflake.ID, member Member, overwrites PermissionOverwrites) Permissions {
+	if basePermissions.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+
+	permissions := basePermissions
+	if overwrite, ok := overwrites.Role(guildID); ok {
+		permissions = permissions.Remove(overwrite.Deny).Add(overwrite.Allow)
+	}
+
+	var (
+		allow Permissions
+		deny  Permissions
+	)
+	for _, roleID := range member.RoleIDs {
+		if roleID == guildID {
+			continue
+		}
+		if overwrite, ok := overwrites.Role(roleID); ok {
+			allow = allow.Add(overwrite.Allow)
+			deny = deny.Add(overwrite.Deny)
+		}
+	}
+	permissions = permissions.Remove(deny).Add(allow)
+
+	if overwrite, ok := overwrites.Member(member.User.ID); ok {
+		permissions = permissions.Remove(overwrite.Deny).Add(overwrite.Allow)
+	}
+	return permissions
+}
+
+// ComputeImplicitPermissions removes the Permissions which are implicitly denied by Discord:
+// Without PermissionViewChannel a Member has no Permissions in a channel at all and
+// without PermissionSendMessages it can't use any of PermissionsRequiringSendMessages.
+func ComputeImplicitPermissions(permissions Permissions) Permissions {
+	if permissions.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+	if permissions.Missing(PermissionViewChannel) {
+		return PermissionsNone
+	}
+	if permissions.Missing(PermissionSendMessages) {
+		permissions = permissions.Remove(PermissionsRequiringSendMessages)
+	}
+	return permissions
+}
+
+// ComputeChannelPermissions computes the effective Permissions of the given Member in the given GuildChannel.
+// It applies the base Permissions, the channel PermissionOverwrites, timeouts and implicit Permissions in this order.
+//
+// GuildThread(s) don't have PermissionOverwrites of their own and inherit them from their parent channel, which needs to be passed as parent.
+// In threads PermissionSendMessages is granted by PermissionSendMessagesInThreads.
+// Whether the Member is allowed to see a private GuildThread depends on its thread membership and needs to be checked separately.
+// For all other channels parent is ignored and may be nil.
+//
+// This only needs data which can be fetched from the rest API, so no cache is required.
+func ComputeChannelPermissions(ownerID snowflake.ID, member Member, roles []Role, channel GuildChannel, parent GuildChannel) Permissions {
+	permissions := ComputeBasePermissions(ownerID, member, roles)
+	if permissions.Has(PermissionAdministrator) {
+		return PermissionsAll
+	}
+
+	overwrites := channel.PermissionOverwrites()
+	_, isThread := channel.(GuildThread)
+	if isThread {
+		overwrites = nil
+		if parent != nil {
+			overwrites = parent.PermissionOverwrites()
+		}
+	}
+	permissions = ComputeOverwrites(permissions, channel.GuildID(), member, overwrites)
+
+	if isThread {
+		if permissions.Has(PermissionSendMessagesInThreads) {
+			permissions = permissions.Add(PermissionSendMessages)
+		} else {
+			permissions = permissions.Remove(PermissionSendMessages)
+		}
+	}
+
+	if member.IsCommunicationDisabled() {
+		permissions &= PermissionsTimedOut
+	}
+	return ComputeImplicitPermissions(permissions)
+}
@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testGuildID   snowflake.ID = 1
+	testOwnerID   snowflake.ID = 2
+	testUserID    snowflake.ID = 3
+	testRoleID    snowflake.ID = 4
+	testChannelID snowflake.ID = 5
+	testThreadID  snowflake.ID = 6
+)
+
+var testRoles = []Role{
+	{ID: testGuildID, Permissions: PermissionViewChannel | PermissionSendMessages | PermissionReadMessageHistory | PermissionSendMessagesInThreads},
+	{ID: testRoleID, Permissions: PermissionAttachFiles | PermissionMentionEveryone},
+}
+
+func testMember(roleIDs ...snowflake.ID) Member {
+	return Member{
+		User:    User{ID: testUserID},
+		RoleIDs: roleIDs,
+		GuildID: testGuildID,
+	}
+}
+
+func testChannel(overwrites ...PermissionOverwrite) GuildTextChannel {
+	return GuildTextChannel{
+		id:                   testChannelID,
+		guildID:              testGuildID,
+		permissionOverwrites: overwrites,
+	}
+}
+
+func TestComputeBasePermissions(t *testing.T) {
+	assert.Equal(t, PermissionsAll, ComputeBasePermissions(testUserID, testMember(), testRoles), "owner should have all permissions")
+	assert.Equal(t, testRoles[0].Permissions, ComputeBasePermissions(testOwnerID, testMember(), testRoles))
+	assert.Equal(t, testRoles[0].Permissions|testRoles[1].Permissions, ComputeBasePermissions(testOwnerID, testMember(testRoleID), testRoles))
+
+	adminRoles := append([]Role{{ID: 7, Permissions: PermissionAdministrator}}, testRoles...)
+	assert.Equal(t, PermissionsAll, ComputeBasePermissions(testOwnerID, testMember(7), adminRoles))
+}
+
+func TestComputeChannelPermissions_Overwrites(t *testing.T) {
+	channel := testChannel(
+		RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionSendMessages},
+		RolePermissionOverwrite{RoleID: testRoleID, Allow: PermissionSendMessages},
+		MemberPermissionOverwrite{UserID: testUserID, Deny: PermissionAttachFiles},
+	)
+
+	permissions := ComputeChannelPermissions(testOwnerID, testMember(testRoleID), testRoles, channel, nil)
+	assert.True(t, permissions.Has(PermissionSendMessages), "role overwrite should allow what the everyone overwrite denies")
+	assert.True(t, permissions.Missing(PermissionAttachFiles), "member overwrite should be applied last")
+
+	permissions = ComputeChannelPermissions(testOwnerID, testMember(), testRoles, channel, nil)
+	assert.True(t, permissions.Missing(PermissionSendMessages))
+}
+
+func TestComputeChannelPermissions_Implicit(t *testing.T) {
+	channel := testChannel(RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionSendMessages})
+	permissions := ComputeChannelPermissions(testOwnerID, testMember(testRoleID), testRoles, channel, nil)
+	assert.True(t, permissions.Missing(PermissionMentionEveryone, PermissionAttachFiles), "no send messages should implicitly deny mention everyone & attach files")
+
+	channel = testChannel(RolePermissionOverwrite{RoleID: testGuildID, Deny: PermissionViewChannel})
+	assert.Equal(t, PermissionsNone, ComputeChannelPermissions(testOwnerID, testMember(testRoleID), testRoles, channel, nil))
+}
+
+func TestComputeChannelPermissions_Timeout(t *testing.T) {
+	until := time.Now().Add(time.Hour)
+	member := testMember(testRoleID)
+	member.CommunicationDisabledUntil = &until
+
+	permissions := ComputeChannelPermissions(testOwnerID, member, testRoles, testChannel(), nil)
+	assert.Equal(t, PermissionViewChannel|PermissionReadMessageHistory, permissions)
+
+	assert.Equal(t, PermissionsAll, ComputeChannelPermissions(testUserID, member, testRoles, testChannel(), nil), "owner should not be affected by timeouts")
+}
+
+func TestComputeChannelPermissions_Thread(t *testing.T) {
+	parent := testChannel(RolePermissionOverwrite{RoleID: testRoleID, Deny: PermissionSendMessagesInThreads})
+	thread := GuildThread{
+		id:          testThreadID,
+		channelType: ChannelTypeGuildPublicThread,
+		guildID:     testGuildID,
+		parentID:    testChannelID,
+	}
+
+	permissions := ComputeChannelPermissions(testOwnerID, testMember(), testRoles, thread, parent)
+	assert.True(t, permissions.Has(PermissionSendMessages), "send messages in threads should grant send messages in threads")
+
+	permissions = ComputeChannelPermissions(testOwnerID, testMember(testRoleID), testRoles, thread, parent)
+	assert.True(t, permissions.Missing(PermissionSendMessages), "thread should inherit the overwrites of its parent")
+	assert.True(t, permissions.Has(PermissionViewChannel))
+}
@@ -35,6 +35,22 @@ func (r Role) Mention() string {
 	return r.String()
 }
 
+// Compare compares the position of the Role to the other Role in the role hierarchy.
+// It returns a negative number if r is lower, a positive number if r is higher and 0 if both are the same Role.
+// Roles with the same position are ordered by their ID, the older Role being higher.
+func (r Role) Compare(other Role) int {
+	if r.Position != other.Position {
+		return r.Position - other.Position
+	}
+	if r.ID == other.ID {
+		return 0
+	}
+	if r.ID < other.ID {
+		return 1
+	}
+	return -1
+}
+
 func (r Role) IconURL(opts ...CDNOpt) *string {
 	if r.Icon == nil {
 		return nil
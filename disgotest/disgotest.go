@@ -0,0 +1,187 @@
+// Package disgotest provides an in-process fake of the Discord gateway and REST API to test bots built on bot.Client without a live Discord.
+//
+// The Server speaks the gateway protocol (HELLO, IDENTIFY, READY, heartbeats, dispatch and RESUME) over a real websocket and records
+// every REST request and gateway message sent by the client, so tests can inject events and assert on the reactions of the bot:
+//
+//	server := disgotest.NewServer()
+//	defer server.Close()
+//
+//	client, _ := disgo.New(server.Token(), append(server.ClientConfigOpts(), bot.WithDefaultGateway())...)
+//	_ = client.OpenGateway(ctx)
+//	_, _ = server.WaitForGatewaySend(ctx, gateway.OpcodeIdentify)
+//
+//	_ = server.Dispatch(gateway.EventTypeMessageCreate, discord.Message{...})
+//	rq, _ := server.WaitForRequest(ctx, http.MethodPost, "/channels/{channel.id}/messages")
+package disgotest
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// NewServer starts a new Server with the given ConfigOpt(s) applied. Call Server.Close after the test.
+func NewServer(opts ...ConfigOpt) *Server {
+	cfg := DefaultConfig()
+	cfg.Apply(opts)
+	cfg.Logger = cfg.Logger.With(slog.String("name", "disgotest"))
+
+	s := &Server{
+		config:   *cfg,
+		sessions: map[string]*session{},
+		conns:    map[*websocket.Conn]struct{}{},
+		changed:  make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gateway", s.serveGateway)
+	mux.Handle(restPrefix+"/", http.StripPrefix(restPrefix, http.HandlerFunc(s.serveRest)))
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+var restPrefix = fmt.Sprintf("/api/v%d", rest.Version)
+
+// Server is a fake Discord gateway and REST API.
+type Server struct {
+	config   Config
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu            sync.Mutex
+	restRoutes    []restRoute
+	requests      []Request
+	gatewaySends  []GatewaySend
+	sessions      map[string]*session
+	conns         map[*websocket.Conn]struct{}
+	nextSessionID int
+	// changed is closed and replaced every time a Request or GatewaySend is recorded
+	changed chan struct{}
+}
+
+// Token returns a bot token for the configured application ID which is accepted by bot.BuildClient.
+func (s *Server) Token() string {
+	return base64.RawStdEncoding.EncodeToString([]byte(s.config.ApplicationID.String())) + ".disgotest.token"
+}
+
+// URL returns the base URL of the Server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// RestURL returns the URL of the fake REST API which can be passed to rest.WithURL.
+func (s *Server) RestURL() string {
+	return s.server.URL + restPrefix
+}
+
+// GatewayURL returns the URL of the fake gateway which can be passed to gateway.WithURL.
+func (s *Server) GatewayURL() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http") + "/gateway"
+}
+
+// ClientConfigOpts returns the bot.ConfigOpt(s) which point the rest.Client of a bot.Client to the Server.
+// The gateway.Gateway and sharding.ShardManager receive the URL of the fake gateway from the REST API.
+func (s *Server) ClientConfigOpts() []bot.ConfigOpt {
+	return []bot.ConfigOpt{
+		bot.WithRestClientConfigOpts(rest.WithURL(s.RestURL())),
+	}
+}
+
+// Close disconnects all gateway sessions and shuts down the Server.
+func (s *Server) Close() {
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+	s.server.Close()
+}
+
+// Requests returns all recorded REST requests in the order they were received.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// WaitForRequest returns the first recorded Request matching the method and route, waiting for it until the context.Context is done.
+// The route uses the format of rest.Endpoint, where every {placeholder} matches a single path segment. For example "/channels/{channel.id}/messages".
+func (s *Server) WaitForRequest(ctx context.Context, method string, route string) (Request, error) {
+	var rq Request
+	err := s.waitFor(ctx, func() bool {
+		for _, r := range s.requests {
+			if r.Method == method && matchRoute(route, r.Path) {
+				rq = r
+				return true
+			}
+		}
+		return false
+	})
+	return rq, err
+}
+
+// GatewaySends returns all recorded gateway messages sent by the clients in the order they were received.
+func (s *Server) GatewaySends() []GatewaySend {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]GatewaySend(nil), s.gatewaySends...)
+}
+
+// WaitForGatewaySend returns the first recorded GatewaySend with the given gateway.Opcode, waiting for it until the context.Context is done.
+func (s *Server) WaitForGatewaySend(ctx context.Context, op gateway.Opcode) (GatewaySend, error) {
+	var send GatewaySend
+	err := s.waitFor(ctx, func() bool {
+		for _, gs := range s.gatewaySends {
+			if gs.Op == op {
+				send = gs
+				return true
+			}
+		}
+		return false
+	})
+	return send, err
+}
+
+// Reset removes all recorded Request(s) and GatewaySend(s).
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+	s.gatewaySends = nil
+}
+
+// waitFor calls match with the lock held until it returns true or the context.Context is done.
+func (s *Server) waitFor(ctx context.Context, match func() bool) error {
+	for {
+		s.mu.Lock()
+		if match() {
+			s.mu.Unlock()
+			return nil
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("disgotest: %w", ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// notify wakes up all waitFor calls. It must be called with the lock held.
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
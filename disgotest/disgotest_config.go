@@ -0,0 +1,87 @@
+package disgotest
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:            slog.Default(),
+		ApplicationID:     snowflake.ID(1000000000000000001),
+		HeartbeatInterval: 45 * time.Second,
+	}
+}
+
+// Config lets you configure your Server instance.
+type Config struct {
+	// Logger is the logger of the Server. Defaults to slog.Default()
+	Logger *slog.Logger
+	// ApplicationID is the ID of the fake application and bot user. It is encoded in Server.Token. Defaults to 1000000000000000001.
+	ApplicationID snowflake.ID
+	// SelfUser is the bot user sent in the READY event. Defaults to a bot user with the ApplicationID named "disgotest".
+	SelfUser *discord.OAuth2User
+	// Guilds are the guilds sent as unavailable in the READY event. Dispatch a gateway.EventTypeGuildCreate to make them available.
+	Guilds []snowflake.ID
+	// HeartbeatInterval is the heartbeat interval sent in the HELLO message. Defaults to 45s.
+	HeartbeatInterval time.Duration
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.SelfUser == nil {
+		c.SelfUser = &discord.OAuth2User{
+			User: discord.User{
+				ID:       c.ApplicationID,
+				Username: "disgotest",
+				Bot:      true,
+			},
+		}
+	}
+}
+
+// WithLogger sets the logger of the Server.
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithApplicationID sets the ID of the fake application.
+func WithApplicationID(applicationID snowflake.ID) ConfigOpt {
+	return func(config *Config) {
+		config.ApplicationID = applicationID
+	}
+}
+
+// WithSelfUser sets the bot user sent in the READY event.
+func WithSelfUser(user discord.OAuth2User) ConfigOpt {
+	return func(config *Config) {
+		config.SelfUser = &user
+	}
+}
+
+// WithGuilds sets the guilds sent as unavailable in the READY event.
+func WithGuilds(guildIDs ...snowflake.ID) ConfigOpt {
+	return func(config *Config) {
+		config.Guilds = append(config.Guilds, guildIDs...)
+	}
+}
+
+// WithHeartbeatInterval sets the heartbeat interval sent in the HELLO message.
+func WithHeartbeatInterval(interval time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.HeartbeatInterval = interval
+	}
+}
@@ -0,0 +1,75 @@
+package disgotest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestServer(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := NewServer(WithLogger(logger))
+	defer server.Close()
+
+	channelID := snowflake.ID(2000000000000000002)
+	server.RespondRest(http.MethodPost, "/channels/{channel.id}/messages", http.StatusOK, discord.Message{ID: 3000000000000000003, ChannelID: channelID, Content: "pong"})
+
+	client, err := disgo.New(server.Token(), append(server.ClientConfigOpts(),
+		bot.WithLogger(logger),
+		bot.WithGatewayConfigOpts(gateway.WithIntents(gateway.IntentGuildMessages, gateway.IntentMessageContent)),
+		bot.WithEventListenerFunc(func(e *events.MessageCreate) {
+			if e.Message.Content == "ping" {
+				_, _ = e.Client().Rest().CreateMessage(e.ChannelID, discord.MessageCreate{Content: "pong"})
+			}
+		}),
+	)...)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	defer client.Close(ctx)
+
+	require.NoError(t, client.OpenGateway(ctx))
+	identify, err := server.WaitForGatewaySend(ctx, gateway.OpcodeIdentify)
+	require.NoError(t, err)
+	var identifyData gateway.MessageDataIdentify
+	require.NoError(t, identify.Unmarshal(&identifyData))
+	assert.Equal(t, server.Token(), identifyData.Token)
+	assert.Equal(t, gateway.IntentGuildMessages|gateway.IntentMessageContent, identifyData.Intents)
+
+	require.NoError(t, server.Dispatch(gateway.EventTypeMessageCreate, discord.Message{
+		ID:        4000000000000000004,
+		ChannelID: channelID,
+		Content:   "ping",
+		Author:    discord.User{ID: 5000000000000000005, Username: "user"},
+	}))
+
+	rq, err := server.WaitForRequest(ctx, http.MethodPost, "/channels/{channel.id}/messages")
+	require.NoError(t, err)
+	assert.Equal(t, "/channels/"+channelID.String()+"/messages", rq.Path)
+	var messageCreate discord.MessageCreate
+	require.NoError(t, rq.Unmarshal(&messageCreate))
+	assert.Equal(t, "pong", messageCreate.Content)
+
+	require.NoError(t, server.Reconnect())
+	resume, err := server.WaitForGatewaySend(ctx, gateway.OpcodeResume)
+	require.NoError(t, err)
+	var resumeData gateway.MessageDataResume
+	require.NoError(t, resume.Unmarshal(&resumeData))
+	assert.Equal(t, "disgotest-1", resumeData.SessionID)
+	assert.Equal(t, 2, resumeData.Seq)
+	assert.Equal(t, resumeData.SessionID, *client.Gateway().SessionID())
+}
@@ -0,0 +1,258 @@
+package disgotest
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/disgoorg/json"
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// ErrNoSession is returned by Server.Dispatch when no client has identified yet.
+var ErrNoSession = errors.New("disgotest: no connected gateway session")
+
+// GatewaySend is a gateway message sent by a client to the Server.
+type GatewaySend struct {
+	Op gateway.Opcode
+	// Data is the raw JSON of the d field.
+	Data json.RawMessage
+}
+
+// Unmarshal decodes the Data of the GatewaySend into v.
+func (s GatewaySend) Unmarshal(v any) error {
+	return json.Unmarshal(s.Data, v)
+}
+
+type payload struct {
+	Op gateway.Opcode    `json:"op"`
+	S  *int              `json:"s"`
+	T  gateway.EventType `json:"t,omitempty"`
+	D  any               `json:"d"`
+}
+
+// session is an identified gateway session which can be resumed on a new connection.
+type session struct {
+	id    string
+	shard [2]int
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	seq  int
+}
+
+func (s *session) dispatch(eventType gateway.EventType, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return ErrNoSession
+	}
+	s.seq++
+	seq := s.seq
+	return s.conn.WriteJSON(payload{Op: gateway.OpcodeDispatch, S: &seq, T: eventType, D: data})
+}
+
+func (s *session) send(op gateway.Opcode, data any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return ErrNoSession
+	}
+	return s.conn.WriteJSON(payload{Op: op, D: data})
+}
+
+// Dispatch sends the event with the given data to all connected gateway sessions.
+// The data is encoded as JSON, so you can pass the structs of the discord or gateway package.
+func (s *Server) Dispatch(eventType gateway.EventType, data any) error {
+	return s.sendSessions(func(session *session) error {
+		return session.dispatch(eventType, data)
+	})
+}
+
+// DispatchShard sends the event with the given data to the gateway session of the given shard.
+func (s *Server) DispatchShard(shardID int, eventType gateway.EventType, data any) error {
+	return s.sendSessions(func(session *session) error {
+		if session.shard[0] != shardID {
+			return ErrNoSession
+		}
+		return session.dispatch(eventType, data)
+	})
+}
+
+// Reconnect asks all connected gateway sessions to reconnect with gateway.OpcodeReconnect. The clients resume their sessions afterward.
+func (s *Server) Reconnect() error {
+	return s.sendSessions(func(session *session) error {
+		return session.send(gateway.OpcodeReconnect, nil)
+	})
+}
+
+// sendSessions calls f for all sessions and returns ErrNoSession if it did not succeed for any of them.
+func (s *Server) sendSessions(f func(session *session) error) error {
+	s.mu.Lock()
+	sessions := make([]*session, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.mu.Unlock()
+
+	var (
+		sent bool
+		errs []error
+	)
+	for _, session := range sessions {
+		if err := f(session); err != nil {
+			if !errors.Is(err, ErrNoSession) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		sent = true
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if !sent {
+		return ErrNoSession
+	}
+	return nil
+}
+
+func (s *Server) serveGateway(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.config.Logger.Error("failed to upgrade gateway connection", slog.Any("err", err))
+		return
+	}
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	var current *session
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		if current != nil {
+			current.mu.Lock()
+			if current.conn == conn {
+				current.conn = nil
+			}
+			current.mu.Unlock()
+		}
+		_ = conn.Close()
+	}()
+
+	// the connection isn't shared before IDENTIFY or RESUME, so no lock is needed
+	if err = conn.WriteJSON(payload{Op: gateway.OpcodeHello, D: gateway.MessageDataHello{HeartbeatInterval: int(s.config.HeartbeatInterval.Milliseconds())}}); err != nil {
+		return
+	}
+
+	for {
+		var message struct {
+			Op gateway.Opcode  `json:"op"`
+			D  json.RawMessage `json:"d"`
+		}
+		if err = conn.ReadJSON(&message); err != nil {
+			return
+		}
+
+		switch message.Op {
+		case gateway.OpcodeHeartbeat:
+			if current != nil {
+				err = current.send(gateway.OpcodeHeartbeatACK, nil)
+			} else {
+				err = conn.WriteJSON(payload{Op: gateway.OpcodeHeartbeatACK})
+			}
+
+		case gateway.OpcodeIdentify:
+			var identify gateway.MessageDataIdentify
+			if err = json.Unmarshal(message.D, &identify); err != nil {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(int(gateway.CloseEventCodeDecodeError.Code), "decode error"))
+				return
+			}
+			current = s.newSession(conn, identify)
+			if err = current.dispatch(gateway.EventTypeReady, s.ready(current)); err == nil {
+				s.addSession(current)
+			}
+
+		case gateway.OpcodeResume:
+			var resume gateway.MessageDataResume
+			if err = json.Unmarshal(message.D, &resume); err != nil {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(int(gateway.CloseEventCodeDecodeError.Code), "decode error"))
+				return
+			}
+			s.mu.Lock()
+			resumed, ok := s.sessions[resume.SessionID]
+			s.mu.Unlock()
+			if !ok {
+				err = conn.WriteJSON(payload{Op: gateway.OpcodeInvalidSession, D: false})
+				break
+			}
+			resumed.mu.Lock()
+			resumed.conn = conn
+			resumed.mu.Unlock()
+			current = resumed
+			err = current.dispatch(gateway.EventTypeResumed, nil)
+		}
+		if err != nil {
+			s.config.Logger.Debug("failed to write gateway message", slog.Any("err", err))
+			return
+		}
+
+		// record after handling the message, so the session can receive events once an IDENTIFY or RESUME was recorded
+		s.mu.Lock()
+		s.gatewaySends = append(s.gatewaySends, GatewaySend{Op: message.Op, Data: message.D})
+		s.notify()
+		s.mu.Unlock()
+	}
+}
+
+func (s *Server) newSession(conn *websocket.Conn, identify gateway.MessageDataIdentify) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextSessionID++
+	session := &session{
+		id:    "disgotest-" + strconv.Itoa(s.nextSessionID),
+		shard: [2]int{0, 1},
+		conn:  conn,
+	}
+	if identify.Shard != nil {
+		session.shard = *identify.Shard
+	}
+	return session
+}
+
+// addSession registers the session for Server.Dispatch. A new session replaces the previous session of the same shard.
+func (s *Server) addSession(session *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, old := range s.sessions {
+		if old.shard[0] == session.shard[0] {
+			delete(s.sessions, id)
+		}
+	}
+	s.sessions[session.id] = session
+}
+
+func (s *Server) ready(session *session) gateway.EventReady {
+	guilds := make([]discord.UnavailableGuild, 0, len(s.config.Guilds))
+	for _, guildID := range s.config.Guilds {
+		guilds = append(guilds, discord.UnavailableGuild{ID: guildID, Unavailable: true})
+	}
+	return gateway.EventReady{
+		Version:          gateway.Version,
+		User:             *s.config.SelfUser,
+		Guilds:           guilds,
+		SessionID:        session.id,
+		ResumeGatewayURL: s.GatewayURL(),
+		Shard:            session.shard,
+		Application: discord.PartialApplication{
+			ID: s.config.ApplicationID,
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package disgotest
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Request is a REST request received by the Server.
+type Request struct {
+	Method string
+	// Path is the path of the request without the API version prefix. For example "/channels/123/messages".
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Unmarshal decodes the JSON body of the Request into v. For multipart requests the payload_json part is decoded.
+func (r Request) Unmarshal(v any) error {
+	body := r.Body
+	if mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(bytes.NewReader(r.Body), params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				return err
+			}
+			if part.FormName() == "payload_json" {
+				if body, err = io.ReadAll(part); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return json.Unmarshal(body, v)
+}
+
+// RestHandlerFunc returns the status code and the JSON encoded body of the response for a Request. A nil body sends no body.
+type RestHandlerFunc func(rq Request) (status int, body any)
+
+type restRoute struct {
+	method  string
+	route   string
+	handler RestHandlerFunc
+}
+
+// HandleRest registers the RestHandlerFunc for all requests matching the method and route. Routes registered later take precedence.
+// The route uses the format of rest.Endpoint, where every {placeholder} matches a single path segment. For example "/channels/{channel.id}/messages".
+//
+// Requests without a matching handler receive a 204 No Content response, except for rest.GetGateway and rest.GetGatewayBot which point to the fake gateway.
+// Register a handler for every request which expects a response body.
+func (s *Server) HandleRest(method string, route string, handler RestHandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.restRoutes = append(s.restRoutes, restRoute{
+		method:  method,
+		route:   route,
+		handler: handler,
+	})
+}
+
+// RespondRest registers a static response for all requests matching the method and route. See HandleRest.
+func (s *Server) RespondRest(method string, route string, status int, body any) {
+	s.HandleRest(method, route, func(Request) (int, any) {
+		return status, body
+	})
+}
+
+func (s *Server) serveRest(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rq := Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, rq)
+	s.notify()
+	handler := s.restHandler(rq)
+	s.mu.Unlock()
+
+	status, rsBody := handler(rq)
+	if rsBody == nil {
+		w.WriteHeader(status)
+		return
+	}
+	data, err := json.Marshal(rsBody)
+	if err != nil {
+		s.config.Logger.Error("failed to encode response body", slog.String("path", rq.Path), slog.Any("err", err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// restHandler returns the RestHandlerFunc for the Request. It must be called with the lock held.
+func (s *Server) restHandler(rq Request) RestHandlerFunc {
+	for i := len(s.restRoutes) - 1; i >= 0; i-- {
+		route := s.restRoutes[i]
+		if route.method == rq.Method && matchRoute(route.route, rq.Path) {
+			return route.handler
+		}
+	}
+
+	switch {
+	case rq.Method == http.MethodGet && rq.Path == "/gateway":
+		return func(Request) (int, any) {
+			return http.StatusOK, discord.Gateway{URL: s.GatewayURL()}
+		}
+	case rq.Method == http.MethodGet && rq.Path == "/gateway/bot":
+		return func(Request) (int, any) {
+			return http.StatusOK, discord.GatewayBot{
+				URL:    s.GatewayURL(),
+				Shards: 1,
+				SessionStartLimit: discord.SessionStartLimit{
+					Total:          1000,
+					Remaining:      1000,
+					MaxConcurrency: 1,
+				},
+			}
+		}
+	}
+	return func(Request) (int, any) {
+		return http.StatusNoContent, nil
+	}
+}
+
+func matchRoute(route string, path string) bool {
+	routeParts := strings.Split(strings.Trim(route, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(routeParts) != len(pathParts) {
+		return false
+	}
+	for i, part := range routeParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -114,6 +114,11 @@ type Gateway interface {
 	// If the context is done, the Gateway connection will be killed.
 	CloseWithCode(ctx context.Context, code int, message string)
 
+	// StopDispatch stops passing dispatch events to the EventHandlerFunc until the Gateway is opened again.
+	// Dispatch events received afterward don't update the LastSequenceReceived, so they are replayed when the session is resumed.
+	// This is used for graceful shutdowns together with CloseWithCode and a resumable close code like websocket.CloseServiceRestart.
+	StopDispatch()
+
 	// Status returns the Status of the Gateway.
 	Status() Status
 
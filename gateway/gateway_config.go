@@ -4,6 +4,8 @@ import (
 	"log/slog"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/metrics"
 )
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -19,6 +21,7 @@ func DefaultConfig() *Config {
 		ShardCount:      1,
 		AutoReconnect:   true,
 		EnableResumeURL: true,
+		Metrics:         metrics.Noop,
 	}
 }
 
@@ -51,6 +54,9 @@ type Config struct {
 	AutoReconnect bool
 	// EnableRawEvents is whether the Gateway should emit EventRaw. Defaults to false.
 	EnableRawEvents bool
+	// EventDecodeFilter decides whether the payload of a dispatch event with the given EventType should be decoded. Defaults to nil (decode all events).
+	// Payloads which are not decoded are only passed as EventRaw with EventTypeRaw. EventTypeReady and EventTypeResumed are always decoded.
+	EventDecodeFilter func(eventType EventType) bool
 	// EnableResumeURL is whether the Gateway should enable the resumeURL. Defaults to true.
 	EnableResumeURL bool
 	// RateLimiter is the RateLimiter of the Gateway. Defaults to NewRateLimiter().
@@ -65,6 +71,8 @@ type Config struct {
 	Browser string
 	// Device is the Device it should send on login. Defaults to "disgo".
 	Device string
+	// Metrics is the metrics.Recorder which receives event counts, heartbeat latencies and reconnects. Defaults to metrics.Noop.
+	Metrics metrics.Recorder
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
@@ -171,6 +179,15 @@ func WithEnableRawEvents(enableRawEventEvents bool) ConfigOpt {
 	}
 }
 
+// WithEventDecodeFilter sets a filter which decides whether the payload of a dispatch event should be decoded.
+// Skipping events saves CPU time, but the bot.Client won't update its caches or dispatch high level events for them.
+// Payloads which are not decoded are passed as EventRaw with EventTypeRaw instead.
+func WithEventDecodeFilter(filter func(eventType EventType) bool) ConfigOpt {
+	return func(config *Config) {
+		config.EventDecodeFilter = filter
+	}
+}
+
 // WithEnableResumeURL enables/disables usage of resume URLs sent by Discord.
 func WithEnableResumeURL(enableResumeURL bool) ConfigOpt {
 	return func(config *Config) {
@@ -226,3 +243,10 @@ func WithDevice(device string) ConfigOpt {
 		config.Device = device
 	}
 }
+
+// WithMetrics sets the metrics.Recorder of the Gateway.
+func WithMetrics(recorder metrics.Recorder) ConfigOpt {
+	return func(config *Config) {
+		config.Metrics = recorder
+	}
+}
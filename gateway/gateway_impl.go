@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -47,6 +48,8 @@ type gatewayImpl struct {
 	heartbeatCancel context.CancelFunc
 	status          Status
 
+	dispatchStopped atomic.Bool
+
 	heartbeatInterval     time.Duration
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
@@ -73,6 +76,7 @@ func (g *gatewayImpl) Intents() Intents {
 }
 
 func (g *gatewayImpl) Open(ctx context.Context) error {
+	g.dispatchStopped.Store(false)
 	return g.reconnectTry(ctx, 0)
 }
 
@@ -131,13 +135,13 @@ func (g *gatewayImpl) Close(ctx context.Context) {
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
 	if g.heartbeatCancel != nil {
 		g.config.Logger.Debug("closing heartbeat goroutines...")
 		g.heartbeatCancel()
+		g.heartbeatCancel = nil
 	}
-
-	g.connMu.Lock()
-	defer g.connMu.Unlock()
 	if g.conn != nil {
 		g.config.RateLimiter.Close(ctx)
 		g.config.Logger.Debug("closing gateway connection", slog.Int("code", code), slog.String("message", message))
@@ -157,6 +161,11 @@ func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message strin
 	g.status = StatusDisconnected
 }
 
+func (g *gatewayImpl) StopDispatch() {
+	g.config.Logger.Debug("stopping event dispatch")
+	g.dispatchStopped.Store(true)
+}
+
 func (g *gatewayImpl) Status() Status {
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
@@ -193,6 +202,8 @@ func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) er
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
@@ -227,17 +238,31 @@ func (g *gatewayImpl) reconnectTry(ctx context.Context, try int) error {
 }
 
 func (g *gatewayImpl) reconnect() {
+	g.config.Metrics.GatewayReconnect(g.config.ShardID)
 	err := g.reconnectTry(context.Background(), 0)
 	if err != nil {
 		g.config.Logger.Error("failed to reopen gateway", slog.Any("err", err))
 	}
 }
 
-func (g *gatewayImpl) heartbeat() {
+// startHeartbeat stops the heartbeat goroutine of a previous Hello and starts a new one with the given interval.
+func (g *gatewayImpl) startHeartbeat(interval time.Duration) {
 	ctx, cancel := context.WithCancel(context.Background())
+
+	g.connMu.Lock()
+	if g.heartbeatCancel != nil {
+		g.heartbeatCancel()
+	}
 	g.heartbeatCancel = cancel
+	g.heartbeatInterval = interval
+	g.lastHeartbeatReceived = time.Now().UTC()
+	g.connMu.Unlock()
+
+	go g.heartbeat(ctx, interval)
+}
 
-	heartbeatTicker := time.NewTicker(g.heartbeatInterval)
+func (g *gatewayImpl) heartbeat(ctx context.Context, interval time.Duration) {
+	heartbeatTicker := time.NewTicker(interval)
 	defer heartbeatTicker.Stop()
 	defer g.config.Logger.Debug("exiting heartbeat goroutine")
 
@@ -247,15 +272,15 @@ func (g *gatewayImpl) heartbeat() {
 			return
 
 		case <-heartbeatTicker.C:
-			g.sendHeartbeat()
+			g.sendHeartbeat(interval)
 		}
 	}
 }
 
-func (g *gatewayImpl) sendHeartbeat() {
+func (g *gatewayImpl) sendHeartbeat(interval time.Duration) {
 	g.config.Logger.Debug("sending heartbeat")
 
-	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
 	defer cancel()
 	if err := g.Send(ctx, OpcodeHeartbeat, MessageDataHeartbeat(*g.config.LastSequenceReceived)); err != nil {
 		if errors.Is(err, discord.ErrShardNotConnected) || errors.Is(err, syscall.EPIPE) {
@@ -268,7 +293,9 @@ func (g *gatewayImpl) sendHeartbeat() {
 		go g.reconnect()
 		return
 	}
+	g.connMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
+	g.connMu.Unlock()
 }
 
 func (g *gatewayImpl) identify() {
@@ -378,9 +405,7 @@ loop:
 
 		switch message.Op {
 		case OpcodeHello:
-			g.heartbeatInterval = time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond
-			g.lastHeartbeatReceived = time.Now().UTC()
-			go g.heartbeat()
+			g.startHeartbeat(time.Duration(message.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond)
 
 			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
 				g.identify()
@@ -389,8 +414,14 @@ loop:
 			}
 
 		case OpcodeDispatch:
+			// drop the event without acknowledging its sequence, so it gets replayed on resume
+			if g.dispatchStopped.Load() {
+				continue
+			}
+
 			// set last sequence received
 			g.config.LastSequenceReceived = &message.S
+			g.config.Metrics.GatewayEventReceived(g.config.ShardID, string(message.T))
 
 			eventData, ok := message.D.(EventData)
 			if !ok && message.D != nil {
@@ -411,6 +442,12 @@ loop:
 				continue
 			}
 
+			// the payload was not decoded, see Config.EventDecodeFilter
+			if rawEvent, ok := eventData.(EventRaw); ok {
+				g.eventHandlerFunc(EventTypeRaw, message.S, g.config.ShardID, rawEvent)
+				continue
+			}
+
 			// push message to the command manager
 			if g.config.EnableRawEvents {
 				g.eventHandlerFunc(EventTypeRaw, message.S, g.config.ShardID, EventRaw{
@@ -421,7 +458,7 @@ loop:
 			g.eventHandlerFunc(message.T, message.S, g.config.ShardID, eventData)
 
 		case OpcodeHeartbeat:
-			g.sendHeartbeat()
+			g.sendHeartbeat(g.heartbeatInterval)
 
 		case OpcodeReconnect:
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -451,11 +488,17 @@ loop:
 
 		case OpcodeHeartbeatACK:
 			newHeartbeat := time.Now().UTC()
+			g.connMu.Lock()
+			lastHeartbeat := g.lastHeartbeatReceived
+			g.lastHeartbeatReceived = newHeartbeat
+			latency := newHeartbeat.Sub(g.lastHeartbeatSent)
+			g.connMu.Unlock()
+
 			g.eventHandlerFunc(EventTypeHeartbeatAck, message.S, g.config.ShardID, EventHeartbeatAck{
-				LastHeartbeat: g.lastHeartbeatReceived,
+				LastHeartbeat: lastHeartbeat,
 				NewHeartbeat:  newHeartbeat,
 			})
-			g.lastHeartbeatReceived = newHeartbeat
+			g.config.Metrics.GatewayHeartbeatLatency(g.config.ShardID, latency)
 
 		default:
 
@@ -487,6 +530,27 @@ func (g *gatewayImpl) parseMessage(mt int, r io.Reader) (Message, error) {
 		r = buff
 	}
 
+	var v rawMessage
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return Message{}, err
+	}
+
+	if v.Op == OpcodeDispatch && g.config.EventDecodeFilter != nil && v.T != EventTypeReady && v.T != EventTypeResumed && !g.config.EventDecodeFilter(v.T) {
+		return Message{
+			Op: v.Op,
+			S:  v.S,
+			T:  v.T,
+			D: EventRaw{
+				EventType: v.T,
+				Payload:   bytes.NewReader(v.D),
+			},
+			RawD: v.D,
+		}, nil
+	}
+
 	var message Message
-	return message, json.NewDecoder(r).Decode(&message)
+	if err := message.unmarshalData(v); err != nil {
+		return Message{}, err
+	}
+	return message, nil
 }
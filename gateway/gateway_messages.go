@@ -18,17 +18,24 @@ type Message struct {
 	RawD json.RawMessage `json:"-"`
 }
 
+// rawMessage is a Message with its data not yet unmarshalled
+type rawMessage struct {
+	Op Opcode          `json:"op"`
+	S  int             `json:"s,omitempty"`
+	T  EventType       `json:"t,omitempty"`
+	D  json.RawMessage `json:"d,omitempty"`
+}
+
 func (e *Message) UnmarshalJSON(data []byte) error {
-	var v struct {
-		Op Opcode          `json:"op"`
-		S  int             `json:"s,omitempty"`
-		T  EventType       `json:"t,omitempty"`
-		D  json.RawMessage `json:"d,omitempty"`
-	}
+	var v rawMessage
 	if err := json.Unmarshal(data, &v); err != nil {
 		return err
 	}
+	return e.unmarshalData(v)
+}
 
+// unmarshalData unmarshalls the data of the rawMessage depending on its Opcode and EventType
+func (e *Message) unmarshalData(v rawMessage) error {
 	var (
 		messageData MessageData
 		err         error
@@ -93,7 +100,7 @@ func (e *Message) UnmarshalJSON(data []byte) error {
 		messageData = d
 	}
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal message data: %s: %w", string(data), err)
+		return fmt.Errorf("failed to unmarshal message data: %s: %w", string(v.D), err)
 	}
 	e.Op = v.Op
 	e.S = v.S
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const (
+	// InitialResponseTimeout is the time after the creation of an interaction in which it has to be acknowledged.
+	InitialResponseTimeout = 3 * time.Second
+
+	// TokenTimeout is the time after the creation of an interaction in which its token can be used for follow-up messages.
+	TokenTimeout = 15 * time.Minute
+)
+
+// acknowledgement tracks the initial response of an interaction.
+// It is shared by all copies of an InteractionEvent and the events created from it.
+type acknowledgement struct {
+	// respond sends the initial response. Events converted with CommandEvent.InteractionEvent and similar respond through the
+	// tracked Respond of the original event, so the acknowledgement calls the original responder directly to avoid tracking responses twice.
+	respond events.InteractionResponderFunc
+
+	// mu is never held during requests
+	mu sync.Mutex
+	// pending is closed once the initial response which is currently sent succeeded or failed.
+	pending      chan struct{}
+	responseType discord.InteractionResponseType
+	// ephemeral is true if the initial response was an ephemeral deferred message.
+	ephemeral bool
+	// updated is true once the deferred message is edited.
+	updated bool
+}
+
+func (a *acknowledgement) acknowledged() bool {
+	if a == nil {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.responseType != 0
+}
+
+// send sends the initial response or, if the interaction was already acknowledged, redirects it:
+//   - a deferred response after any response is a no-op
+//   - a message after a deferred message updates the deferred message, further messages are sent as follow-up messages
+//   - an ephemeral message after a public deferred message is sent as ephemeral follow-up message, as the deferred message can't become ephemeral
+//   - a message with a poll, tts or stickers after a deferred message is sent as follow-up message, as they can't be added by updating a message
+//   - a message after any other response is sent as follow-up message
+//   - a message update after a deferred response or message update updates the original response
+//
+// All other responses return discord.ErrInteractionAlreadyReplied.
+// Responses sent while the initial response is in flight wait for it, so they are redirected if it succeeds.
+func (a *acknowledgement) send(e *InteractionEvent, responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts []rest.RequestOpt) error {
+	opts = requestOpts(e.Ctx, opts)
+	if a == nil {
+		return e.InteractionCreate.Respond(responseType, data, opts...)
+	}
+
+	a.mu.Lock()
+	for a.pending != nil {
+		pending := a.pending
+		a.mu.Unlock()
+		<-pending
+		a.mu.Lock()
+	}
+
+	if a.responseType == 0 {
+		pending := make(chan struct{})
+		a.pending = pending
+		a.mu.Unlock()
+
+		err := a.respond(responseType, data, opts...)
+
+		a.mu.Lock()
+		if err == nil {
+			a.responseType = responseType
+			if messageCreate, ok := data.(discord.MessageCreate); ok && responseType == discord.InteractionResponseTypeDeferredCreateMessage {
+				a.ephemeral = messageCreate.Flags.Has(discord.MessageFlagEphemeral)
+			}
+		}
+		a.pending = nil
+		a.mu.Unlock()
+		close(pending)
+		return err
+	}
+	acknowledgedType := a.responseType
+
+	switch responseType {
+	case discord.InteractionResponseTypeDeferredCreateMessage, discord.InteractionResponseTypeDeferredUpdateMessage:
+		a.mu.Unlock()
+		return nil
+
+	case discord.InteractionResponseTypeCreateMessage:
+		messageCreate, ok := data.(discord.MessageCreate)
+		if !ok {
+			a.mu.Unlock()
+			return fmt.Errorf("unexpected message create data %T", data)
+		}
+		ephemeralOnly := messageCreate.Flags.Has(discord.MessageFlagEphemeral) && !a.ephemeral
+		update := acknowledgedType == discord.InteractionResponseTypeDeferredCreateMessage && !a.updated && !ephemeralOnly && canUpdateMessage(messageCreate)
+		if update {
+			// claim the deferred message, so concurrent messages are sent as follow-up messages
+			a.updated = true
+		}
+		a.mu.Unlock()
+
+		if update {
+			if _, err := e.Client().Rest().UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageCreateToUpdate(messageCreate), opts...); err != nil {
+				a.mu.Lock()
+				a.updated = false
+				a.mu.Unlock()
+				return err
+			}
+			return nil
+		}
+		_, err := e.Client().Rest().CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, opts...)
+		return err
+
+	case discord.InteractionResponseTypeUpdateMessage:
+		a.mu.Unlock()
+		if acknowledgedType == discord.InteractionResponseTypeCreateMessage {
+			break
+		}
+		messageUpdate, ok := data.(discord.MessageUpdate)
+		if !ok {
+			return fmt.Errorf("unexpected message update data %T", data)
+		}
+		_, err := e.Client().Rest().UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, opts...)
+		return err
+
+	default:
+		a.mu.Unlock()
+	}
+	return discord.ErrInteractionAlreadyReplied
+}
+
+// canUpdateMessage returns whether the discord.MessageCreate can be converted with messageCreateToUpdate without losing any fields.
+func canUpdateMessage(m discord.MessageCreate) bool {
+	return m.Poll == nil && !m.TTS && len(m.StickerIDs) == 0
+}
+
+// messageCreateToUpdate converts a discord.MessageCreate to a discord.MessageUpdate which replaces the content of a deferred message.
+// The ephemeral flag can't be changed after deferring and is removed. Messages for which canUpdateMessage returns false lose fields.
+func messageCreateToUpdate(m discord.MessageCreate) discord.MessageUpdate {
+	update := discord.MessageUpdate{
+		Content:         &m.Content,
+		Files:           m.Files,
+		AllowedMentions: m.AllowedMentions,
+	}
+	if len(m.Embeds) > 0 {
+		update.Embeds = &m.Embeds
+	}
+	if len(m.Components) > 0 {
+		update.Components = &m.Components
+	}
+	if len(m.Attachments) > 0 {
+		attachments := make([]discord.AttachmentUpdate, 0, len(m.Attachments))
+		for _, attachment := range m.Attachments {
+			attachments = append(attachments, attachment)
+		}
+		update.Attachments = &attachments
+	}
+	if flags := m.Flags.Remove(discord.MessageFlagEphemeral); flags != discord.MessageFlagsNone {
+		update.Flags = &flags
+	}
+	return update
+}
+
+// expiresAt returns the time until the interaction with the id has to be acknowledged or, if acknowledged, until its token expires.
+func expiresAt(id snowflake.ID, acknowledged bool) time.Time {
+	if acknowledged {
+		return id.Time().Add(TokenTimeout)
+	}
+	return id.Time().Add(InitialResponseTimeout)
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler/handlertest"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestAcknowledgement(t *testing.T) {
+	mux := New()
+	mux.SlashCommand("/defer", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		assert.False(t, e.Acknowledged())
+		assert.WithinDuration(t, e.ID().Time().Add(InitialResponseTimeout), e.ExpiresAt(), 0)
+		require.NoError(t, e.DeferCreateMessage(true))
+
+		ie := e.InteractionEvent()
+		assert.True(t, ie.Acknowledged(), "acknowledgement should be shared")
+		assert.WithinDuration(t, e.ID().Time().Add(TokenTimeout), ie.ExpiresAt(), 0)
+		require.NoError(t, ie.DeferCreateMessage(false), "deferring again should do nothing")
+
+		require.NoError(t, e.CreateMessage(discord.MessageCreate{Content: "first", Flags: discord.MessageFlagEphemeral}))
+		require.NoError(t, e.CreateMessage(discord.MessageCreate{Content: "second"}))
+		assert.ErrorIs(t, e.Modal(discord.ModalCreate{CustomID: "modal"}), discord.ErrInteractionAlreadyReplied)
+		return nil
+	})
+	mux.SlashCommand("/public", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		require.NoError(t, e.DeferCreateMessage(false))
+		require.NoError(t, e.CreateMessage(discord.MessageCreate{Content: "private", Flags: discord.MessageFlagEphemeral}))
+		return e.CreateMessage(discord.MessageCreate{Content: "public"})
+	})
+	mux.ButtonComponent("/button", func(data discord.ButtonInteractionData, e *ComponentEvent) error {
+		require.NoError(t, e.DeferUpdateMessage())
+		return e.UpdateMessage(discord.NewMessageUpdateBuilder().SetContent("updated").Build())
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/defer"))
+	responses := rec.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, responses[0].Type)
+	updates := rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "first", *updates[0].Content)
+	assert.Nil(t, updates[0].Flags, "ephemeral flag should be removed")
+	followups := rec.Followups()
+	require.Len(t, followups, 1)
+	assert.Equal(t, "second", followups[0].Content)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/public"))
+	followups = rec.Followups()
+	require.Len(t, followups, 1, "ephemeral messages must not replace a public deferred message")
+	assert.Equal(t, discord.MessageCreate{Content: "private", Flags: discord.MessageFlagEphemeral}, followups[0])
+	updates = rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "public", *updates[0].Content)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewButtonInteraction("/button"))
+	require.Len(t, rec.Responses(), 1)
+	updates = rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "updated", *updates[0].Content)
+}
+
+func TestAcknowledgementWithoutMux(t *testing.T) {
+	rec := handlertest.NewRecorder()
+	e := &InteractionEvent{InteractionCreate: rec.Event(handlertest.NewSlashCommandInteraction("/test"))}
+	require.NoError(t, e.CreateMessage(discord.MessageCreate{Content: "test"}))
+	assert.False(t, e.Acknowledged(), "events not created by a Mux are not tracked")
+	assert.WithinDuration(t, time.Now().Add(InitialResponseTimeout), e.ExpiresAt(), time.Second)
+}
+
+func TestAcknowledgementPoll(t *testing.T) {
+	mux := New()
+	mux.SlashCommand("/poll", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		require.NoError(t, e.DeferCreateMessage(false))
+		require.NoError(t, e.CreateMessage(discord.MessageCreate{Poll: &discord.PollCreate{Question: discord.PollMedia{Text: json.Ptr("question")}}}))
+		return e.CreateMessage(discord.MessageCreate{Content: "done"})
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/poll"))
+	followups := rec.Followups()
+	require.Len(t, followups, 1, "polls can't be added to the deferred message")
+	assert.NotNil(t, followups[0].Poll)
+	updates := rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "done", *updates[0].Content)
+}
+
+func TestAcknowledgementPending(t *testing.T) {
+	rec := handlertest.NewRecorder()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	e := &InteractionEvent{
+		InteractionCreate: rec.Event(handlertest.NewSlashCommandInteraction("/test")),
+		ack: &acknowledgement{respond: func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+			close(started)
+			<-release
+			return rec.Respond(responseType, data, opts...)
+		}},
+	}
+
+	deferred := make(chan error)
+	go func() {
+		deferred <- e.DeferCreateMessage(false)
+	}()
+	<-started
+	assert.False(t, e.Acknowledged(), "the acknowledgement must not be locked while the response is sent")
+
+	created := make(chan error)
+	go func() {
+		created <- e.CreateMessage(discord.MessageCreate{Content: "test"})
+	}()
+	close(release)
+	require.NoError(t, <-deferred)
+	require.NoError(t, <-created)
+
+	require.Len(t, rec.Responses(), 1)
+	updates := rec.ResponseUpdates()
+	require.Len(t, updates, 1, "messages sent while deferring should update the deferred message")
+	assert.Equal(t, "test", *updates[0].Content)
+}
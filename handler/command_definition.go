@@ -0,0 +1,379 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var (
+	_ CommandDefinition = (*SlashCommand)(nil)
+	_ CommandDefinition = (*UserCommand)(nil)
+	_ CommandDefinition = (*MessageCommand)(nil)
+)
+
+// CommandDefinition defines an application command together with its handlers.
+// It is used to generate both the discord.ApplicationCommandCreate to register the command and the routes of the Mux.
+type CommandDefinition interface {
+	// CommandName returns the name of the command.
+	CommandName() string
+
+	// ApplicationCommandCreate returns the discord.ApplicationCommandCreate to register the command.
+	ApplicationCommandCreate() discord.ApplicationCommandCreate
+
+	// paths returns the paths of the command which can be routed.
+	paths() []commandPath
+
+	// register registers the handlers of the command to the Router.
+	register(r Router)
+}
+
+// SlashCommand defines a slash command with its options, subcommands and handlers.
+// A SlashCommand either has a Handler and Options or Subcommands and SubcommandGroups.
+type SlashCommand struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	Options                  []discord.ApplicationCommandOption
+	DefaultMemberPermissions *json.Nullable[discord.Permissions]
+	IntegrationTypes         []discord.ApplicationIntegrationType
+	Contexts                 []discord.InteractionContextType
+	NSFW                     bool
+
+	Subcommands      []Subcommand
+	SubcommandGroups []SubcommandGroup
+
+	Handler      SlashCommandHandler
+	Autocomplete AutocompleteHandler
+}
+
+func (c SlashCommand) CommandName() string {
+	return c.Name
+}
+
+func (c SlashCommand) ApplicationCommandCreate() discord.ApplicationCommandCreate {
+	options := slices.Clone(c.Options)
+	for _, group := range c.SubcommandGroups {
+		options = append(options, group.option())
+	}
+	for _, sub := range c.Subcommands {
+		options = append(options, sub.option())
+	}
+	return discord.SlashCommandCreate{
+		Name:                     c.Name,
+		NameLocalizations:        c.NameLocalizations,
+		Description:              c.Description,
+		DescriptionLocalizations: c.DescriptionLocalizations,
+		Options:                  options,
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+		IntegrationTypes:         c.IntegrationTypes,
+		Contexts:                 c.Contexts,
+		NSFW:                     nsfw(c.NSFW),
+	}
+}
+
+func (c SlashCommand) paths() []commandPath {
+	if len(c.Subcommands) == 0 && len(c.SubcommandGroups) == 0 {
+		return []commandPath{{path: "/" + c.Name, commandType: discord.ApplicationCommandTypeSlash, autocomplete: hasAutocomplete(c.Options)}}
+	}
+	var paths []commandPath
+	for _, group := range c.SubcommandGroups {
+		for _, sub := range group.Subcommands {
+			paths = append(paths, commandPath{path: "/" + c.Name + "/" + group.Name + "/" + sub.Name, commandType: discord.ApplicationCommandTypeSlash, autocomplete: hasAutocomplete(sub.Options)})
+		}
+	}
+	for _, sub := range c.Subcommands {
+		paths = append(paths, commandPath{path: "/" + c.Name + "/" + sub.Name, commandType: discord.ApplicationCommandTypeSlash, autocomplete: hasAutocomplete(sub.Options)})
+	}
+	return paths
+}
+
+func (c SlashCommand) register(r Router) {
+	if len(c.Subcommands) == 0 && len(c.SubcommandGroups) == 0 {
+		registerSlashCommand(r, "/"+c.Name, c.Handler, c.Autocomplete)
+		return
+	}
+	if c.Handler != nil {
+		panic("slash command /" + c.Name + " with subcommands must not have a handler")
+	}
+	if len(c.Options) > 0 {
+		panic("slash command /" + c.Name + " with subcommands must not have options")
+	}
+	for _, group := range c.SubcommandGroups {
+		for _, sub := range group.Subcommands {
+			registerSlashCommand(r, "/"+c.Name+"/"+group.Name+"/"+sub.Name, sub.Handler, sub.Autocomplete)
+		}
+	}
+	for _, sub := range c.Subcommands {
+		registerSlashCommand(r, "/"+c.Name+"/"+sub.Name, sub.Handler, sub.Autocomplete)
+	}
+}
+
+// hasAutocomplete returns true if any of the options has autocomplete enabled.
+func hasAutocomplete(options []discord.ApplicationCommandOption) bool {
+	for _, option := range options {
+		switch o := option.(type) {
+		case discord.ApplicationCommandOptionString:
+			if o.Autocomplete {
+				return true
+			}
+		case discord.ApplicationCommandOptionInt:
+			if o.Autocomplete {
+				return true
+			}
+		case discord.ApplicationCommandOptionFloat:
+			if o.Autocomplete {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func registerSlashCommand(r Router, path string, h SlashCommandHandler, autocomplete AutocompleteHandler) {
+	if h == nil {
+		panic("slash command " + path + " has no handler")
+	}
+	r.SlashCommand(path, h)
+	if autocomplete != nil {
+		r.Autocomplete(path, autocomplete)
+	}
+}
+
+// Subcommand defines a subcommand of a SlashCommand or SubcommandGroup.
+type Subcommand struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	Options                  []discord.ApplicationCommandOption
+
+	Handler      SlashCommandHandler
+	Autocomplete AutocompleteHandler
+}
+
+func (s Subcommand) option() discord.ApplicationCommandOptionSubCommand {
+	return discord.ApplicationCommandOptionSubCommand{
+		Name:                     s.Name,
+		NameLocalizations:        s.NameLocalizations,
+		Description:              s.Description,
+		DescriptionLocalizations: s.DescriptionLocalizations,
+		Options:                  s.Options,
+	}
+}
+
+// SubcommandGroup defines a group of Subcommand(s) of a SlashCommand.
+type SubcommandGroup struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	Subcommands              []Subcommand
+}
+
+func (g SubcommandGroup) option() discord.ApplicationCommandOptionSubCommandGroup {
+	options := make([]discord.ApplicationCommandOptionSubCommand, 0, len(g.Subcommands))
+	for _, sub := range g.Subcommands {
+		options = append(options, sub.option())
+	}
+	return discord.ApplicationCommandOptionSubCommandGroup{
+		Name:                     g.Name,
+		NameLocalizations:        g.NameLocalizations,
+		Description:              g.Description,
+		DescriptionLocalizations: g.DescriptionLocalizations,
+		Options:                  options,
+	}
+}
+
+// UserCommand defines a user context menu command with its handler.
+type UserCommand struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	DefaultMemberPermissions *json.Nullable[discord.Permissions]
+	IntegrationTypes         []discord.ApplicationIntegrationType
+	Contexts                 []discord.InteractionContextType
+	NSFW                     bool
+
+	Handler UserCommandHandler
+}
+
+func (c UserCommand) CommandName() string {
+	return c.Name
+}
+
+func (c UserCommand) ApplicationCommandCreate() discord.ApplicationCommandCreate {
+	return discord.UserCommandCreate{
+		Name:                     c.Name,
+		NameLocalizations:        c.NameLocalizations,
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+		IntegrationTypes:         c.IntegrationTypes,
+		Contexts:                 c.Contexts,
+		NSFW:                     nsfw(c.NSFW),
+	}
+}
+
+func (c UserCommand) paths() []commandPath {
+	return []commandPath{{path: "/" + c.Name, commandType: discord.ApplicationCommandTypeUser}}
+}
+
+func (c UserCommand) register(r Router) {
+	if c.Handler == nil {
+		panic("user command /" + c.Name + " has no handler")
+	}
+	r.UserCommand("/"+c.Name, c.Handler)
+}
+
+// MessageCommand defines a message context menu command with its handler.
+type MessageCommand struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	DefaultMemberPermissions *json.Nullable[discord.Permissions]
+	IntegrationTypes         []discord.ApplicationIntegrationType
+	Contexts                 []discord.InteractionContextType
+	NSFW                     bool
+
+	Handler MessageCommandHandler
+}
+
+func (c MessageCommand) CommandName() string {
+	return c.Name
+}
+
+func (c MessageCommand) ApplicationCommandCreate() discord.ApplicationCommandCreate {
+	return discord.MessageCommandCreate{
+		Name:                     c.Name,
+		NameLocalizations:        c.NameLocalizations,
+		DefaultMemberPermissions: c.DefaultMemberPermissions,
+		IntegrationTypes:         c.IntegrationTypes,
+		Contexts:                 c.Contexts,
+		NSFW:                     nsfw(c.NSFW),
+	}
+}
+
+func (c MessageCommand) paths() []commandPath {
+	return []commandPath{{path: "/" + c.Name, commandType: discord.ApplicationCommandTypeMessage}}
+}
+
+func (c MessageCommand) register(r Router) {
+	if c.Handler == nil {
+		panic("message command /" + c.Name + " has no handler")
+	}
+	r.MessageCommand("/"+c.Name, c.Handler)
+}
+
+func nsfw(nsfw bool) *bool {
+	if !nsfw {
+		return nil
+	}
+	return &nsfw
+}
+
+// ApplicationCommandCreates returns the discord.ApplicationCommandCreate(s) of the given CommandDefinition(s).
+func ApplicationCommandCreates(commands ...CommandDefinition) []discord.ApplicationCommandCreate {
+	creates := make([]discord.ApplicationCommandCreate, 0, len(commands))
+	for _, command := range commands {
+		creates = append(creates, command.ApplicationCommandCreate())
+	}
+	return creates
+}
+
+// SyncMuxCommands checks the routes of the Mux with Mux.CheckCommands and then syncs all CommandDefinition(s) registered to the Mux with SyncCommands.
+func SyncMuxCommands(client bot.Client, mux *Mux, guildIDs []snowflake.ID, opts ...rest.RequestOpt) error {
+	if err := mux.CheckCommands(); err != nil {
+		return err
+	}
+	return SyncCommands(client, ApplicationCommandCreates(mux.CommandDefinitions()...), guildIDs, opts...)
+}
+
+// commandPath is a path of a CommandDefinition which can be routed. Autocomplete is true if the command has options with autocomplete enabled.
+type commandPath struct {
+	path         string
+	commandType  discord.ApplicationCommandType
+	autocomplete bool
+}
+
+// commandRoute is a route of a Router which handles application commands or autocomplete interactions.
+type commandRoute struct {
+	pattern         string
+	interactionType discord.InteractionType
+	commandTypes    []int
+}
+
+func (r commandRoute) matches(path commandPath) bool {
+	if r.interactionType == discord.InteractionTypeAutocomplete && !path.autocomplete {
+		return false
+	}
+	if len(r.commandTypes) > 0 && !slices.Contains(r.commandTypes, int(path.commandType)) {
+		return false
+	}
+	parts := splitPath(path.path)
+	patternParts := splitPath(r.pattern)
+	if len(parts) != len(patternParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		if part != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// commandRoutes returns all application command and autocomplete routes of the Route with their full pattern.
+func commandRoutes(prefix string, route Route) []commandRoute {
+	switch route := route.(type) {
+	case *Mux:
+		var routes []commandRoute
+		for _, r := range route.routes {
+			routes = append(routes, commandRoutes(prefix+route.pattern, r)...)
+		}
+		return routes
+	case commandRouteHolder:
+		pattern, interactionType, commandTypes := route.commandRoute()
+		if interactionType != discord.InteractionTypeApplicationCommand && interactionType != discord.InteractionTypeAutocomplete {
+			return nil
+		}
+		return []commandRoute{{pattern: prefix + pattern, interactionType: interactionType, commandTypes: commandTypes}}
+	}
+	return nil
+}
+
+type commandRouteHolder interface {
+	commandRoute() (string, discord.InteractionType, []int)
+}
+
+func (h *handlerHolder[T]) commandRoute() (string, discord.InteractionType, []int) {
+	return h.pattern, h.t, h.t2
+}
+
+// checkCommandRoutes returns an error for every command route which doesn't match any path of the CommandDefinition(s).
+func checkCommandRoutes(routes []commandRoute, commands []CommandDefinition) error {
+	var paths []commandPath
+	for _, command := range commands {
+		paths = append(paths, command.paths()...)
+	}
+
+	var errs []error
+	for _, route := range routes {
+		if !slices.ContainsFunc(paths, route.matches) {
+			kind := "command"
+			if route.interactionType == discord.InteractionTypeAutocomplete {
+				kind = "autocomplete"
+			}
+			errs = append(errs, fmt.Errorf("%s route %s does not match any registered command", kind, route.pattern))
+		}
+	}
+	return errors.Join(errs...)
+}
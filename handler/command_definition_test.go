@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCommandDefinitions(t *testing.T) {
+	noop := func(data discord.SlashCommandInteractionData, e *CommandEvent) error { return nil }
+	tag := SlashCommand{
+		Name:                     "tag",
+		Description:              "Manage tags",
+		DescriptionLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Tags verwalten"},
+		DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionManageMessages),
+		Subcommands: []Subcommand{
+			{
+				Name:        "get",
+				Description: "Get a tag",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{Name: "name", Description: "The tag", Autocomplete: true},
+				},
+				Handler:      noop,
+				Autocomplete: func(e *AutocompleteEvent) error { return nil },
+			},
+		},
+		SubcommandGroups: []SubcommandGroup{
+			{
+				Name:        "admin",
+				Description: "Tag administration",
+				Subcommands: []Subcommand{{Name: "delete", Description: "Delete a tag", Handler: noop}},
+			},
+		},
+	}
+	info := UserCommand{
+		Name:    "info",
+		Handler: func(data discord.UserCommandInteractionData, e *CommandEvent) error { return nil },
+	}
+
+	mux := New()
+	mux.Commands(tag)
+	sub := New()
+	sub.Commands(info)
+	mux.Mount("", sub)
+
+	assert.Equal(t, []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:                     "tag",
+			Description:              "Manage tags",
+			DescriptionLocalizations: map[discord.Locale]string{discord.LocaleGerman: "Tags verwalten"},
+			DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionManageMessages),
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommandGroup{
+					Name:        "admin",
+					Description: "Tag administration",
+					Options:     []discord.ApplicationCommandOptionSubCommand{{Name: "delete", Description: "Delete a tag"}},
+				},
+				discord.ApplicationCommandOptionSubCommand{
+					Name:        "get",
+					Description: "Get a tag",
+					Options: []discord.ApplicationCommandOption{
+						discord.ApplicationCommandOptionString{Name: "name", Description: "The tag", Autocomplete: true},
+					},
+				},
+			},
+		},
+		discord.UserCommandCreate{Name: "info"},
+	}, ApplicationCommandCreates(mux.CommandDefinitions()...))
+
+	assert.NoError(t, mux.CheckCommands())
+	for _, path := range []string{"/tag/get", "/tag/admin/delete"} {
+		assert.True(t, mux.Match(path, discord.InteractionTypeApplicationCommand, int(discord.ApplicationCommandTypeSlash)), path)
+	}
+	assert.True(t, mux.Match("/tag/get", discord.InteractionTypeAutocomplete, 0))
+	assert.True(t, mux.Match("/info", discord.InteractionTypeApplicationCommand, int(discord.ApplicationCommandTypeUser)))
+
+	mux.SlashCommand("/tag/{action}", noop)
+	assert.NoError(t, mux.CheckCommands(), "route vars should match subcommands")
+
+	mux.SlashCommand("/tags/get", noop)
+	mux.Autocomplete("/tag/admin/delete", func(e *AutocompleteEvent) error { return nil })
+	mux.Route("/info", func(r Router) {
+		r.MessageCommand("/", func(data discord.MessageCommandInteractionData, e *CommandEvent) error { return nil })
+	})
+	mux.Commands(info)
+	err := mux.CheckCommands()
+	assert.ErrorContains(t, err, "command info is registered multiple times")
+	assert.ErrorContains(t, err, "command route /tags/get does not match any registered command")
+	assert.ErrorContains(t, err, "autocomplete route /tag/admin/delete does not match any registered command")
+	assert.ErrorContains(t, err, "command route /info/ does not match any registered command")
+	assert.NotContains(t, err.Error(), "/tag/{action}")
+
+	assert.PanicsWithValue(t, "slash command /mixed with subcommands must not have options", func() {
+		New().Commands(SlashCommand{
+			Name:        "mixed",
+			Options:     []discord.ApplicationCommandOption{discord.ApplicationCommandOptionString{Name: "name"}},
+			Subcommands: []Subcommand{{Name: "get", Handler: noop}},
+		})
+	})
+}
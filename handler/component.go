@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -14,32 +15,61 @@ type ComponentEvent struct {
 	*events.ComponentInteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// State is the restored State or nil if the custom id has no state. See Mux.State.
+	State *State
+
+	ack *acknowledgement
+}
+
+// InteractionEvent returns the ComponentEvent as InteractionEvent which shares the Vars and Ctx.
+func (e *ComponentEvent) InteractionEvent() *InteractionEvent {
+	return &InteractionEvent{
+		InteractionCreate: &events.InteractionCreate{
+			GenericEvent: e.GenericEvent,
+			Interaction:  e.ComponentInteraction,
+			Respond:      e.Respond,
+		},
+		Vars:  e.Vars,
+		Ctx:   e.Ctx,
+		State: e.State,
+		ack:   e.ack,
+	}
+}
+
+// Acknowledged returns whether the interaction was already responded to or deferred. See InteractionEvent.Respond.
+func (e *ComponentEvent) Acknowledged() bool {
+	return e.ack.acknowledged()
+}
+
+// ExpiresAt returns the time until the interaction has to be acknowledged or, if it was acknowledged, until follow-up messages can be sent.
+func (e *ComponentEvent) ExpiresAt() time.Time {
+	return expiresAt(e.ID(), e.Acknowledged())
 }
 
 func (e *ComponentEvent) GetInteractionResponse(opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().GetInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest().GetInteractionResponse(e.ApplicationID(), e.Token(), requestOpts(e.Ctx, opts)...)
 }
 
 func (e *ComponentEvent) UpdateInteractionResponse(messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, opts...)
+	return e.Client().Rest().UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *ComponentEvent) DeleteInteractionResponse(opts ...rest.RequestOpt) error {
-	return e.Client().Rest().DeleteInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest().DeleteInteractionResponse(e.ApplicationID(), e.Token(), requestOpts(e.Ctx, opts)...)
 }
 
 func (e *ComponentEvent) GetFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest().GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *ComponentEvent) CreateFollowupMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, opts...)
+	return e.Client().Rest().CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *ComponentEvent) UpdateFollowupMessage(messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, opts...)
+	return e.Client().Rest().UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *ComponentEvent) DeleteFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) error {
-	return e.Client().Rest().DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest().DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, requestOpts(e.Ctx, opts)...)
 }
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+const (
+	// FlowActionCancel is the action of FlowSession.CancelCustomID which cancels the flow.
+	FlowActionCancel = "cancel"
+	// FlowActionRetry is the action of the button which enters the current step again after the Validate of a step submitted with a modal failed.
+	FlowActionRetry = "retry"
+)
+
+var (
+	// ErrFlowFinished is returned when transitioning a FlowSession which is already finished or cancelled.
+	ErrFlowFinished = errors.New("flow is already finished")
+
+	// ErrUnknownFlowStep is returned when transitioning a FlowSession to a step which does not exist.
+	ErrUnknownFlowStep = errors.New("unknown flow step")
+)
+
+// Flow is a multi-step conversation with a single user, for example a modal followed by a select menu and a confirm button.
+// Each step renders its message or modal in Enter and handles the components or modals of the message in Component or Modal.
+// Handlers move the FlowSession to another step with FlowSession.Next, FlowSession.Goto, FlowSession.Finish or FlowSession.Cancel
+// and the Flow then validates the step and responds with the Enter of the next step, OnFinish or OnCancel.
+// If a handler does not transition, it must respond itself.
+//
+// The FlowSession is stored with NewState, so the Mux needs a StateStore configured with Mux.State. It is only resumed by the user who started the flow.
+type Flow[T any] struct {
+	// Path is the prefix of the custom ids of the flow. The custom ids have the format Path/action#state.
+	Path string
+	// Steps are the steps of the flow. The flow starts at the first step and FlowSession.Next moves to the following step.
+	Steps []FlowStep[T]
+	// Timeout is the time the user has to complete the flow. If zero, only the ttl of the StateStore applies.
+	Timeout time.Duration
+
+	// OnFinish responds to the interaction which finished the flow. If nil, the flow responds with an ephemeral message.
+	OnFinish func(s *FlowSession[T], e *InteractionEvent) error
+	// OnCancel responds to the interaction which cancelled the flow. If nil, the flow responds with an ephemeral message.
+	OnCancel func(s *FlowSession[T], e *InteractionEvent) error
+	// OnTimeout responds to interactions of timed out or expired flows. If nil, the flow responds with an ephemeral message.
+	OnTimeout func(e *InteractionEvent) error
+	// OnWrongUser responds to interactions of other users than the one who started the flow. If nil, the flow responds with an ephemeral message.
+	OnWrongUser func(e *InteractionEvent) error
+}
+
+// FlowStep is a single step of a Flow.
+type FlowStep[T any] struct {
+	// Name is the unique name of the step used by FlowSession.Goto.
+	Name string
+	// Enter responds to the interaction which moved the flow to this step, for example with a modal or a message with components which use FlowSession.CustomID.
+	Enter func(s *FlowSession[T], e *InteractionEvent) error
+	// Component handles the components of this step.
+	Component func(s *FlowSession[T], e *ComponentEvent) error
+	// Modal handles the modals of this step.
+	Modal func(s *FlowSession[T], e *ModalEvent) error
+	// Validate validates the data before the flow leaves this step with FlowSession.Next, FlowSession.Goto or FlowSession.Finish.
+	// The message of the error is shown to the user as ephemeral message and the flow stays at this step.
+	// As a modal can't be answered with another modal, the message has a retry button which runs Enter again if the step was submitted with a modal.
+	Validate func(data T) error
+}
+
+// flowState is the stored state of a FlowSession.
+type flowState[T any] struct {
+	UserID    snowflake.ID `json:"user_id"`
+	Step      int          `json:"step"`
+	Data      T            `json:"data"`
+	ExpiresAt time.Time    `json:"expires_at,omitempty"`
+}
+
+type flowTransition int
+
+const (
+	flowTransitionNone flowTransition = iota
+	flowTransitionStep
+	flowTransitionFinish
+	flowTransitionCancel
+)
+
+// FlowSession is a running Flow of a single user.
+type FlowSession[T any] struct {
+	// Data is the data collected by the flow. Changes are stored after each handler.
+	Data T
+
+	flow       *Flow[T]
+	state      *State
+	userID     snowflake.ID
+	step       int
+	expiresAt  time.Time
+	transition flowTransition
+	nextStep   int
+}
+
+// Step returns the name of the current step.
+func (s *FlowSession[T]) Step() string {
+	return s.flow.Steps[s.step].Name
+}
+
+// UserID returns the id of the user who started the flow.
+func (s *FlowSession[T]) UserID() snowflake.ID {
+	return s.userID
+}
+
+// CustomID returns the custom id for a component or modal of the flow with the given action. The action is available as Vars["action"].
+// It returns an error if the custom id is longer than the 100 characters allowed by Discord.
+func (s *FlowSession[T]) CustomID(action string) (string, error) {
+	return s.state.CustomID(s.flow.Path + "/" + action)
+}
+
+// CancelCustomID returns the custom id for a button which cancels the flow.
+// It returns an error if the custom id is longer than the 100 characters allowed by Discord.
+func (s *FlowSession[T]) CancelCustomID() (string, error) {
+	return s.CustomID(FlowActionCancel)
+}
+
+// Next moves the flow to the next step or finishes it after the last step.
+func (s *FlowSession[T]) Next() {
+	if s.step+1 >= len(s.flow.Steps) {
+		s.Finish()
+		return
+	}
+	s.transition = flowTransitionStep
+	s.nextStep = s.step + 1
+}
+
+// Goto moves the flow to the step with the given name.
+func (s *FlowSession[T]) Goto(step string) error {
+	for i, flowStep := range s.flow.Steps {
+		if flowStep.Name == step {
+			s.transition = flowTransitionStep
+			s.nextStep = i
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownFlowStep, step)
+}
+
+// Finish finishes the flow after the current handler.
+func (s *FlowSession[T]) Finish() {
+	s.transition = flowTransitionFinish
+}
+
+// Cancel cancels the flow after the current handler.
+func (s *FlowSession[T]) Cancel() {
+	s.transition = flowTransitionCancel
+}
+
+// Register registers the component and modal routes of the Flow to the Router. The Router must not have a pattern.
+func (f *Flow[T]) Register(r Router) {
+	checkPattern(f.Path)
+	if len(f.Steps) == 0 {
+		panic("flow " + f.Path + " has no steps")
+	}
+	r.Component(f.Path+"/{action}", func(e *ComponentEvent) error {
+		return f.resume(e.InteractionEvent(), func(step FlowStep[T], s *FlowSession[T]) error {
+			if step.Component == nil {
+				return fmt.Errorf("flow step %s has no component handler", step.Name)
+			}
+			return step.Component(s, e)
+		})
+	})
+	r.Modal(f.Path+"/{action}", func(e *ModalEvent) error {
+		return f.resume(e.InteractionEvent(), func(step FlowStep[T], s *FlowSession[T]) error {
+			if step.Modal == nil {
+				return fmt.Errorf("flow step %s has no modal handler", step.Name)
+			}
+			return step.Modal(s, e)
+		})
+	})
+}
+
+// Start starts the Flow for the user of the interaction with the given data and responds with the Enter of the first step.
+// Use CommandEvent.InteractionEvent to start a flow from a command.
+func (f *Flow[T]) Start(e *InteractionEvent, data T) error {
+	var expiresAt time.Time
+	if f.Timeout > 0 {
+		expiresAt = time.Now().Add(f.Timeout)
+	}
+	state, err := NewState(e.Ctx, flowState[T]{
+		UserID:    e.User().ID,
+		Data:      data,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	return f.enter(&FlowSession[T]{
+		Data:      data,
+		flow:      f,
+		state:     state,
+		userID:    e.User().ID,
+		expiresAt: expiresAt,
+	}, e)
+}
+
+func (f *Flow[T]) resume(e *InteractionEvent, handle func(step FlowStep[T], s *FlowSession[T]) error) error {
+	if e.State == nil {
+		return f.timeout(e)
+	}
+	var fs flowState[T]
+	if err := e.State.Unmarshal(&fs); err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return f.timeout(e)
+		}
+		return err
+	}
+	if !fs.ExpiresAt.IsZero() && time.Now().After(fs.ExpiresAt) {
+		if err := e.State.Delete(e.Ctx); err != nil {
+			return err
+		}
+		return f.timeout(e)
+	}
+	if fs.UserID != e.User().ID {
+		if f.OnWrongUser != nil {
+			return f.OnWrongUser(e)
+		}
+		return flowMessage(e, "This is not your interaction.")
+	}
+	if fs.Step < 0 || fs.Step >= len(f.Steps) {
+		return fmt.Errorf("%w: %d", ErrUnknownFlowStep, fs.Step)
+	}
+
+	s := &FlowSession[T]{
+		Data:      fs.Data,
+		flow:      f,
+		state:     e.State,
+		userID:    fs.UserID,
+		step:      fs.Step,
+		expiresAt: fs.ExpiresAt,
+	}
+	step := f.Steps[s.step]
+	switch e.Vars["action"] {
+	case FlowActionCancel:
+		s.Cancel()
+	case FlowActionRetry:
+		return f.enter(s, e)
+	default:
+		if err := handle(step, s); err != nil {
+			return err
+		}
+	}
+
+	switch s.transition {
+	case flowTransitionCancel:
+		if err := s.state.Delete(e.Ctx); err != nil {
+			return err
+		}
+		if f.OnCancel != nil {
+			return f.OnCancel(s, e)
+		}
+		return flowMessage(e, "Cancelled.")
+	case flowTransitionNone:
+		return f.save(s, e)
+	}
+
+	if step.Validate != nil {
+		if err := step.Validate(s.Data); err != nil {
+			if e.Type() == discord.InteractionTypeModalSubmit {
+				return f.retry(s, e, err.Error())
+			}
+			return flowMessage(e, err.Error())
+		}
+	}
+	if s.transition == flowTransitionFinish {
+		if err := s.state.Delete(e.Ctx); err != nil {
+			return err
+		}
+		if f.OnFinish != nil {
+			return f.OnFinish(s, e)
+		}
+		return flowMessage(e, "Done.")
+	}
+
+	s.step = s.nextStep
+	s.transition = flowTransitionNone
+	if err := f.save(s, e); err != nil {
+		return err
+	}
+	return f.enter(s, e)
+}
+
+func (f *Flow[T]) enter(s *FlowSession[T], e *InteractionEvent) error {
+	step := f.Steps[s.step]
+	if step.Enter == nil {
+		return fmt.Errorf("flow step %s has no enter handler", step.Name)
+	}
+	return step.Enter(s, e)
+}
+
+func (f *Flow[T]) save(s *FlowSession[T], e *InteractionEvent) error {
+	return s.state.Update(e.Ctx, flowState[T]{
+		UserID:    s.userID,
+		Step:      s.step,
+		Data:      s.Data,
+		ExpiresAt: s.expiresAt,
+	})
+}
+
+// retry responds with the message and a button which enters the current step again, so the user can reopen its modal.
+func (f *Flow[T]) retry(s *FlowSession[T], e *InteractionEvent, content string) error {
+	customID, err := s.CustomID(FlowActionRetry)
+	if err != nil {
+		return err
+	}
+	return e.CreateMessage(discord.MessageCreate{
+		Content:    content,
+		Components: []discord.ContainerComponent{discord.NewActionRow(discord.NewPrimaryButton("Retry", customID))},
+		Flags:      discord.MessageFlagEphemeral,
+	})
+}
+
+func (f *Flow[T]) timeout(e *InteractionEvent) error {
+	if f.OnTimeout != nil {
+		return f.OnTimeout(e)
+	}
+	return flowMessage(e, "This interaction has expired.")
+}
+
+func flowMessage(e *InteractionEvent, content string) error {
+	return e.CreateMessage(discord.MessageCreate{
+		Content: content,
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+type signup struct {
+	Name string `json:"name"`
+	Team string `json:"team"`
+}
+
+func TestFlow(t *testing.T) {
+	var customIDs []string
+	flow := &Flow[signup]{
+		Path:    "/signup",
+		Timeout: time.Minute,
+		Steps: []FlowStep[signup]{
+			{
+				Name: "name",
+				Enter: func(s *FlowSession[signup], e *InteractionEvent) error {
+					customID, err := s.CustomID("name")
+					if err != nil {
+						return err
+					}
+					customIDs = append(customIDs, customID)
+					return e.Modal(discord.ModalCreate{CustomID: customID, Title: "Sign up"})
+				},
+				Modal: func(s *FlowSession[signup], e *ModalEvent) error {
+					s.Data.Name = e.Data.Text("name")
+					s.Next()
+					return nil
+				},
+				Validate: func(data signup) error {
+					if len(data.Name) < 3 {
+						return errors.New("name is too short")
+					}
+					return nil
+				},
+			},
+			{
+				Name: "team",
+				Enter: func(s *FlowSession[signup], e *InteractionEvent) error {
+					customID, err := s.CustomID("team")
+					if err != nil {
+						return err
+					}
+					customIDs = append(customIDs, customID)
+					return e.CreateMessage(discord.MessageCreate{Content: "Hi " + s.Data.Name + ", pick a team"})
+				},
+				Component: func(s *FlowSession[signup], e *ComponentEvent) error {
+					s.Data.Team = e.StringSelectMenuInteractionData().Values[0]
+					s.Finish()
+					return nil
+				},
+			},
+		},
+		OnFinish: func(s *FlowSession[signup], e *InteractionEvent) error {
+			return e.CreateMessage(discord.MessageCreate{Content: s.Data.Name + " joined " + s.Data.Team})
+		},
+	}
+
+	mux := New()
+	mux.State(NewMemoryStateStore(), time.Hour)
+	flow.Register(mux)
+	mux.SlashCommand("/signup", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return flow.Start(e.InteractionEvent(), signup{})
+	})
+
+	content := func(rec *handlertest.Recorder) string {
+		response, ok := rec.Response()
+		require.True(t, ok)
+		switch data := response.Data.(type) {
+		case discord.MessageCreate:
+			return data.Content
+		case discord.ModalCreate:
+			return "modal " + data.Title
+		}
+		return ""
+	}
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/signup"))
+	assert.Equal(t, "modal Sign up", content(rec))
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewModalSubmitInteraction(customIDs[0], handlertest.WithModalValue("name", "al")))
+	assert.Equal(t, "name is too short", content(rec), "validation should keep the flow at the step")
+	response, _ := rec.Response()
+	retry := response.Data.(discord.MessageCreate).Components[0].(discord.ActionRowComponent).Components()[0].(discord.ButtonComponent)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewButtonInteraction(retry.CustomID))
+	assert.Equal(t, "modal Sign up", content(rec), "retry should open the modal of the step again")
+	require.Len(t, customIDs, 2)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewModalSubmitInteraction(customIDs[1], handlertest.WithModalValue("name", "alice")))
+	assert.Equal(t, "Hi alice, pick a team", content(rec))
+
+	rec.Reset()
+	other := discord.User{ID: 1300000000000000009, Username: "other"}
+	rec.Serve(mux, handlertest.NewSelectMenuInteraction(customIDs[2], discord.ComponentTypeStringSelectMenu, []string{"red"}, handlertest.WithUser(other)))
+	assert.Equal(t, "This is not your interaction.", content(rec))
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSelectMenuInteraction(customIDs[2], discord.ComponentTypeStringSelectMenu, []string{"blue"}))
+	assert.Equal(t, "alice joined blue", content(rec))
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSelectMenuInteraction(customIDs[2], discord.ComponentTypeStringSelectMenu, []string{"red"}))
+	assert.Equal(t, "This interaction has expired.", content(rec), "finished flows should not resume")
+}
+
+func TestFlowCancelAndTimeout(t *testing.T) {
+	var cancelID string
+	flow := &Flow[signup]{
+		Path:    "/wizard",
+		Timeout: 20 * time.Millisecond,
+		Steps: []FlowStep[signup]{{
+			Name: "confirm",
+			Enter: func(s *FlowSession[signup], e *InteractionEvent) error {
+				var err error
+				if cancelID, err = s.CancelCustomID(); err != nil {
+					return err
+				}
+				return e.CreateMessage(discord.MessageCreate{Content: "confirm?"})
+			},
+		}},
+	}
+	mux := New()
+	mux.State(NewMemoryStateStore(), time.Hour)
+	flow.Register(mux)
+	mux.SlashCommand("/wizard", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return flow.Start(e.InteractionEvent(), signup{})
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/wizard"))
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewButtonInteraction(cancelID))
+	response, _ := rec.Response()
+	assert.Equal(t, discord.MessageCreate{Content: "Cancelled.", Flags: discord.MessageFlagEphemeral}, response.Data)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/wizard"))
+	time.Sleep(30 * time.Millisecond)
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewButtonInteraction(cancelID))
+	response, _ = rec.Response()
+	assert.Equal(t, discord.MessageCreate{Content: "This interaction has expired.", Flags: discord.MessageFlagEphemeral}, response.Data)
+}
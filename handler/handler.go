@@ -14,6 +14,13 @@
 //
 // The handler iterates over all routes until it finds the fist matching route. If no route matches, the handler will call the NotFoundHandler.
 // The NotFoundHandler can be set via the `NotFound` method on the *Mux. If no NotFoundHandler is set nothing will happen.
+//
+// Commands can also be defined declaratively with SlashCommand, UserCommand and MessageCommand, which contain the name, options, localizations, permissions and handlers in one place.
+// Mux.Commands registers their routes, Mux.CheckCommands reports routes which don't match any registered command and SyncMuxCommands syncs them to Discord.
+// Options can be bound into structs with BindOptions or BindSlashCommand, and CommandOptions generates the matching options from the same struct.
+//
+// Components and modals can carry server-side state which is stored with NewState in the StateStore configured with Mux.State and restored into ComponentEvent.State and ModalEvent.State.
+// Flow builds multi-step conversations like a modal followed by a select menu and a confirm button on top of it.
 
 package handler
 
@@ -28,9 +35,11 @@ import (
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
 	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 // SyncCommands sets the given commands for the given guilds or globally if no guildIDs are empty. It will return on the first error for multiple guilds.
+// It overwrites all commands, use SyncCommandsIncremental to only create, update or delete the commands which changed.
 func SyncCommands(client bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...rest.RequestOpt) error {
 	if len(guildIDs) == 0 {
 		_, err := client.Rest().SetGlobalCommands(client.ApplicationID(), commands, opts...)
@@ -73,7 +82,15 @@ func (h *handlerHolder[T]) Match(path string, t discord.InteractionType, t2 int)
 
 func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 	parseVariables(path, h.pattern, event.Vars)
+	event.appendRoute(h.pattern)
 
+	if tracingEnabled(event.Ctx) {
+		return traceHandler("handler.handle", h.handle, tracing.String("handler.pattern", h.pattern))(event)
+	}
+	return h.handle(event)
+}
+
+func (h *handlerHolder[T]) handle(event *InteractionEvent) error {
 	switch handler := any(h.handler).(type) {
 	case InteractionHandler:
 		return handler(event)
@@ -86,6 +103,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
+			ack:  event.ack,
 		})
 	case SlashCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -97,6 +115,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
+			ack:  event.ack,
 		})
 	case UserCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -108,6 +127,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
+			ack:  event.ack,
 		})
 	case MessageCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -119,6 +139,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
+			ack:  event.ack,
 		})
 	case EntryPointCommandHandler:
 		commandInteraction := event.Interaction.(discord.ApplicationCommandInteraction)
@@ -130,6 +151,7 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 			},
 			Vars: event.Vars,
 			Ctx:  event.Ctx,
+			ack:  event.ack,
 		})
 	case AutocompleteHandler:
 		return handler(&AutocompleteEvent{
@@ -148,8 +170,10 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: event.Interaction.(discord.ComponentInteraction),
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
+			ack:   event.ack,
 		})
 	case ButtonComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -159,8 +183,10 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
+			ack:   event.ack,
 		})
 	case SelectMenuComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -170,8 +196,10 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
+			ack:   event.ack,
 		})
 	case ModalHandler:
 		return handler(&ModalEvent{
@@ -180,8 +208,10 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ModalSubmitInteraction: event.Interaction.(discord.ModalSubmitInteraction),
 				Respond:                event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
+			ack:   event.ack,
 		})
 	}
 	return errors.New("unknown handler type")
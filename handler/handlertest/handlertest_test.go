@@ -0,0 +1,102 @@
+package handlertest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+func TestSlashCommand(t *testing.T) {
+	target := discord.User{ID: 1300000000000000002, Username: "target"}
+
+	mux := handler.New()
+	mux.Route("/mod", func(r handler.Router) {
+		r.SlashCommand("/ban", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+			if !e.Member().Permissions.Has(discord.PermissionBanMembers) {
+				return e.CreateMessage(discord.MessageCreate{Content: "missing permissions"})
+			}
+			member := data.Member("user")
+			if err := e.DeferCreateMessage(true); err != nil {
+				return err
+			}
+			_, err := e.CreateFollowupMessage(discord.MessageCreate{
+				Content: "banned " + member.User.Username + " for " + strconv.Itoa(data.Int("days")) + " days",
+			})
+			return err
+		})
+	})
+
+	rec := NewRecorder()
+	rec.Serve(mux, NewSlashCommandInteraction("/mod/ban",
+		WithPermissions(discord.PermissionBanMembers),
+		WithOption("user", discord.Member{User: target}),
+		WithOption("days", 7),
+	))
+
+	response, ok := rec.Response()
+	require.True(t, ok)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, response.Type)
+	require.Len(t, rec.Followups(), 1)
+	assert.Equal(t, "banned target for 7 days", rec.Followups()[0].Content)
+
+	rec.Reset()
+	rec.Serve(mux, NewSlashCommandInteraction("/mod/ban", WithOption("user", target)))
+	assert.Equal(t, []discord.InteractionResponse{{
+		Type: discord.InteractionResponseTypeCreateMessage,
+		Data: discord.MessageCreate{Content: "missing permissions"},
+	}}, rec.Responses())
+	assert.Empty(t, rec.Followups())
+}
+
+func TestComponentAndModal(t *testing.T) {
+	mux := handler.New()
+	mux.ButtonComponent("/vote/{choice}", func(data discord.ButtonInteractionData, e *handler.ComponentEvent) error {
+		if e.Context() != discord.InteractionContextTypeBotDM {
+			return e.CreateMessage(discord.MessageCreate{Content: "dm only"})
+		}
+		choice := e.Vars["choice"]
+		return e.UpdateMessage(discord.MessageUpdate{Content: &choice})
+	})
+	mux.Modal("/feedback", func(e *handler.ModalEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: e.Data.Text("text")})
+	})
+
+	rec := NewRecorder()
+	rec.Serve(mux, NewButtonInteraction("/vote/yes", WithDM()))
+	response, ok := rec.Response()
+	require.True(t, ok)
+	assert.Equal(t, discord.InteractionResponseTypeUpdateMessage, response.Type)
+
+	rec.Reset()
+	rec.Serve(mux, NewButtonInteraction("/vote/yes"))
+	response, _ = rec.Response()
+	assert.Equal(t, discord.MessageCreate{Content: "dm only"}, response.Data)
+
+	rec.Reset()
+	rec.Serve(mux, NewModalSubmitInteraction("/feedback", WithModalValue("text", "great bot")))
+	response, _ = rec.Response()
+	assert.Equal(t, discord.MessageCreate{Content: "great bot"}, response.Data)
+}
+
+func TestAutocomplete(t *testing.T) {
+	mux := handler.New()
+	mux.Autocomplete("/tag/get", func(e *handler.AutocompleteEvent) error {
+		option := e.Data.Focused()
+		return e.AutocompleteResult([]discord.AutocompleteChoice{
+			discord.AutocompleteChoiceString{Name: option.Name, Value: e.Data.String("name") + "!"},
+		})
+	})
+
+	rec := NewRecorder()
+	rec.Serve(mux, NewAutocompleteInteraction("/tag/get", "name", "hel", WithOption("limit", 5)))
+	response, ok := rec.Response()
+	require.True(t, ok)
+	assert.Equal(t, discord.AutocompleteResult{Choices: []discord.AutocompleteChoice{
+		discord.AutocompleteChoiceString{Name: "name", Value: "hel!"},
+	}}, response.Data)
+}
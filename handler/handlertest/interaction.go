@@ -0,0 +1,241 @@
+// Package handlertest provides utilities to unit-test handler.Mux routes without Discord.
+//
+// The builders create realistic interactions by encoding them like Discord does and decoding them with discord.UnmarshalInteraction.
+// The Recorder captures all responses, response updates and follow-up messages of the handlers:
+//
+//	rec := handlertest.NewRecorder()
+//	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/ban",
+//		handlertest.WithPermissions(discord.PermissionBanMembers),
+//		handlertest.WithOption("user", target),
+//	))
+//	response, _ := rec.Response()
+package handlertest
+
+import (
+	"strings"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// NewSlashCommandInteraction returns a slash command interaction for the given command path. The path can contain a subcommand group and subcommand,
+// for example "/settings/log/channel". Use WithOption to add options.
+func NewSlashCommandInteraction(path string, opts ...InteractionOpt) discord.ApplicationCommandInteraction {
+	cfg := newConfig(opts)
+	name, options := commandOptions(path, cfg.options)
+	return unmarshal[discord.ApplicationCommandInteraction](cfg, discord.InteractionTypeApplicationCommand, map[string]any{
+		"id":       newID(),
+		"name":     name,
+		"type":     discord.ApplicationCommandTypeSlash,
+		"guild_id": cfg.GuildID,
+		"options":  options,
+		"resolved": cfg.Resolved,
+	}, nil)
+}
+
+// NewUserCommandInteraction returns a user command interaction with the given name targeting the user.
+// In guilds, the target is resolved as member of the guild.
+func NewUserCommandInteraction(name string, target discord.User, opts ...InteractionOpt) discord.ApplicationCommandInteraction {
+	cfg := newConfig(opts)
+	resolved := discord.UserCommandResolved{
+		Users: map[snowflake.ID]discord.User{target.ID: target},
+	}
+	if cfg.GuildID != nil {
+		resolved.Members = map[snowflake.ID]discord.ResolvedMember{target.ID: {Member: discord.Member{User: target, GuildID: *cfg.GuildID}}}
+	}
+	return unmarshal[discord.ApplicationCommandInteraction](cfg, discord.InteractionTypeApplicationCommand, map[string]any{
+		"id":        newID(),
+		"name":      name,
+		"type":      discord.ApplicationCommandTypeUser,
+		"guild_id":  cfg.GuildID,
+		"target_id": target.ID,
+		"resolved":  resolved,
+	}, nil)
+}
+
+// NewMessageCommandInteraction returns a message command interaction with the given name targeting the message.
+func NewMessageCommandInteraction(name string, target discord.Message, opts ...InteractionOpt) discord.ApplicationCommandInteraction {
+	cfg := newConfig(opts)
+	return unmarshal[discord.ApplicationCommandInteraction](cfg, discord.InteractionTypeApplicationCommand, map[string]any{
+		"id":        newID(),
+		"name":      name,
+		"type":      discord.ApplicationCommandTypeMessage,
+		"guild_id":  cfg.GuildID,
+		"target_id": target.ID,
+		"resolved": discord.MessageCommandResolved{
+			Messages: map[snowflake.ID]discord.Message{target.ID: target},
+		},
+	}, nil)
+}
+
+// NewAutocompleteInteraction returns an autocomplete interaction for the given command path where the user is typing the value into the focused string option.
+// Use WithOption to add the options which are already filled in.
+func NewAutocompleteInteraction(path string, focused string, value string, opts ...InteractionOpt) discord.AutocompleteInteraction {
+	cfg := newConfig(opts)
+	cfg.options = append(cfg.options, option{name: focused, optionType: discord.ApplicationCommandOptionTypeString, value: value, focused: true})
+	name, options := commandOptions(path, cfg.options)
+	return unmarshal[discord.AutocompleteInteraction](cfg, discord.InteractionTypeAutocomplete, map[string]any{
+		"id":       newID(),
+		"name":     name,
+		"type":     discord.ApplicationCommandTypeSlash,
+		"guild_id": cfg.GuildID,
+		"options":  options,
+	}, nil)
+}
+
+// NewButtonInteraction returns a button interaction with the given custom id. Use WithMessage to set the message of the button.
+func NewButtonInteraction(customID string, opts ...InteractionOpt) discord.ComponentInteraction {
+	cfg := newConfig(opts)
+	return unmarshal[discord.ComponentInteraction](cfg, discord.InteractionTypeComponent, map[string]any{
+		"custom_id":      customID,
+		"component_type": discord.ComponentTypeButton,
+	}, componentMessage(cfg))
+}
+
+// NewSelectMenuInteraction returns a select menu interaction of the given type with the selected values.
+// For user, role, mentionable and channel select menus, the values are IDs and the entities must be added with WithResolved.
+func NewSelectMenuInteraction(customID string, componentType discord.ComponentType, values []string, opts ...InteractionOpt) discord.ComponentInteraction {
+	cfg := newConfig(opts)
+	if values == nil {
+		values = []string{}
+	}
+	return unmarshal[discord.ComponentInteraction](cfg, discord.InteractionTypeComponent, map[string]any{
+		"custom_id":      customID,
+		"component_type": componentType,
+		"values":         values,
+		"resolved":       cfg.Resolved,
+	}, componentMessage(cfg))
+}
+
+// NewModalSubmitInteraction returns a modal submit interaction with the given custom id. Use WithModalValue to add the values of the text inputs.
+func NewModalSubmitInteraction(customID string, opts ...InteractionOpt) discord.ModalSubmitInteraction {
+	cfg := newConfig(opts)
+	rows := make([]map[string]any, 0, len(cfg.modalItems))
+	for _, item := range cfg.modalItems {
+		rows = append(rows, map[string]any{
+			"type": discord.ComponentTypeActionRow,
+			"components": []map[string]any{{
+				"type":      discord.ComponentTypeTextInput,
+				"custom_id": item.customID,
+				"value":     item.value,
+			}},
+		})
+	}
+	return unmarshal[discord.ModalSubmitInteraction](cfg, discord.InteractionTypeModalSubmit, map[string]any{
+		"custom_id":  customID,
+		"components": rows,
+	}, nil)
+}
+
+func newConfig(opts []InteractionOpt) *InteractionConfig {
+	cfg := DefaultInteractionConfig()
+	cfg.Apply(opts)
+	return cfg
+}
+
+// commandOptions nests the options into the subcommand group and subcommand of the path and returns the command name.
+func commandOptions(path string, options []option) (string, []map[string]any) {
+	parts := strings.FieldsFunc(path, func(r rune) bool { return r == '/' })
+	if len(parts) == 0 || len(parts) > 3 {
+		panic("handlertest: invalid command path " + path)
+	}
+
+	raw := make([]map[string]any, 0, len(options))
+	for _, o := range options {
+		rawOption := map[string]any{
+			"name":  o.name,
+			"type":  o.optionType,
+			"value": o.value,
+		}
+		if o.focused {
+			rawOption["focused"] = true
+		}
+		raw = append(raw, rawOption)
+	}
+
+	for i := len(parts) - 1; i > 0; i-- {
+		optionType := discord.ApplicationCommandOptionTypeSubCommand
+		if i == 1 && len(parts) == 3 {
+			optionType = discord.ApplicationCommandOptionTypeSubCommandGroup
+		}
+		raw = []map[string]any{{
+			"name":    parts[i],
+			"type":    optionType,
+			"options": raw,
+		}}
+	}
+	return parts[0], raw
+}
+
+func componentMessage(cfg *InteractionConfig) discord.Message {
+	if cfg.Message != nil {
+		return *cfg.Message
+	}
+	return discord.Message{
+		ID:        newID(),
+		ChannelID: cfg.ChannelID,
+		GuildID:   cfg.GuildID,
+		Author: discord.User{
+			ID:       cfg.ApplicationID,
+			Username: "bot",
+			Bot:      true,
+		},
+	}
+}
+
+// unmarshal encodes the interaction like Discord does and decodes it with discord.UnmarshalInteraction.
+func unmarshal[T discord.Interaction](cfg *InteractionConfig, interactionType discord.InteractionType, data map[string]any, message any) T {
+	raw := map[string]any{
+		"id":              cfg.ID,
+		"type":            interactionType,
+		"application_id":  cfg.ApplicationID,
+		"token":           cfg.Token,
+		"version":         1,
+		"channel_id":      cfg.ChannelID,
+		"locale":          cfg.Locale,
+		"app_permissions": cfg.AppPermissions,
+		"entitlements":    []discord.Entitlement{},
+		"data":            data,
+	}
+	channel := map[string]any{
+		"id":          cfg.ChannelID,
+		"permissions": cfg.AppPermissions,
+	}
+	if cfg.GuildID != nil {
+		raw["guild_id"] = *cfg.GuildID
+		raw["guild_locale"] = cfg.GuildLocale
+		raw["context"] = discord.InteractionContextTypeGuild
+		raw["member"] = discord.ResolvedMember{
+			Member: discord.Member{
+				User:    cfg.User,
+				RoleIDs: cfg.Roles,
+				GuildID: *cfg.GuildID,
+			},
+			Permissions: cfg.Permissions,
+		}
+		channel["type"] = discord.ChannelTypeGuildText
+		channel["name"] = "general"
+		channel["guild_id"] = *cfg.GuildID
+	} else {
+		raw["user"] = cfg.User
+		raw["context"] = discord.InteractionContextTypeBotDM
+		channel["type"] = discord.ChannelTypeDM
+		channel["recipients"] = []discord.User{cfg.User}
+	}
+	raw["channel"] = channel
+	if message != nil {
+		raw["message"] = message
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		panic("handlertest: failed to encode interaction: " + err.Error())
+	}
+	interaction, err := discord.UnmarshalInteraction(b)
+	if err != nil {
+		panic("handlertest: failed to decode interaction: " + err.Error())
+	}
+	return interaction.(T)
+}
@@ -0,0 +1,288 @@
+package handlertest
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Default IDs used by the interaction builders.
+const (
+	DefaultApplicationID = snowflake.ID(1000000000000000001)
+	DefaultGuildID       = snowflake.ID(1100000000000000001)
+	DefaultChannelID     = snowflake.ID(1200000000000000001)
+	DefaultUserID        = snowflake.ID(1300000000000000001)
+)
+
+// DefaultInteractionConfig returns an InteractionConfig of a guild interaction by a member without permissions.
+func DefaultInteractionConfig() *InteractionConfig {
+	guildID := DefaultGuildID
+	return &InteractionConfig{
+		ApplicationID: DefaultApplicationID,
+		GuildID:       &guildID,
+		ChannelID:     DefaultChannelID,
+		User: discord.User{
+			ID:       DefaultUserID,
+			Username: "user",
+		},
+		AppPermissions: discord.PermissionsAllText,
+		Locale:         discord.LocaleEnglishUS,
+	}
+}
+
+// InteractionConfig lets you configure the interactions created by the builders.
+type InteractionConfig struct {
+	// ID is the ID of the interaction. Defaults to a new unique ID.
+	ID snowflake.ID
+	// ApplicationID is the ID of the application receiving the interaction. Defaults to DefaultApplicationID.
+	ApplicationID snowflake.ID
+	// Token is the interaction token. Defaults to a token derived from the ID.
+	Token string
+	// GuildID is the guild the interaction was created in or nil for DMs. Defaults to DefaultGuildID.
+	GuildID *snowflake.ID
+	// ChannelID is the channel the interaction was created in. Defaults to DefaultChannelID.
+	ChannelID snowflake.ID
+	// User is the user who created the interaction. In guilds, it is sent as member.
+	User discord.User
+	// Roles are the role IDs of the member. Only used in guilds.
+	Roles []snowflake.ID
+	// Permissions are the channel permissions of the member. Only used in guilds. Defaults to discord.PermissionsNone.
+	Permissions discord.Permissions
+	// AppPermissions are the channel permissions of the bot. Defaults to discord.PermissionsAllText.
+	AppPermissions discord.Permissions
+	// Locale is the locale of the user. Defaults to discord.LocaleEnglishUS.
+	Locale discord.Locale
+	// GuildLocale is the preferred locale of the guild.
+	GuildLocale *discord.Locale
+	// Resolved is the resolved data of command options and select menus. Entities passed to WithOption are added automatically.
+	Resolved discord.ResolvedData
+	// Message is the message the component is attached to. Only used for component interactions.
+	Message *discord.Message
+
+	options    []option
+	modalItems []modalItem
+}
+
+type option struct {
+	name       string
+	optionType discord.ApplicationCommandOptionType
+	value      any
+	focused    bool
+}
+
+type modalItem struct {
+	customID string
+	value    string
+}
+
+// InteractionOpt is a type alias for a function that takes an InteractionConfig and is used to configure the created interactions.
+type InteractionOpt func(config *InteractionConfig)
+
+// Apply applies the given InteractionOpt(s) to the InteractionConfig
+func (c *InteractionConfig) Apply(opts []InteractionOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.ID == 0 {
+		c.ID = newID()
+	}
+	if c.Token == "" {
+		c.Token = "handlertest-" + c.ID.String()
+	}
+}
+
+// WithInteractionID sets the ID of the interaction.
+func WithInteractionID(id snowflake.ID) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.ID = id
+	}
+}
+
+// WithApplicationID sets the ID of the application receiving the interaction.
+func WithApplicationID(applicationID snowflake.ID) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.ApplicationID = applicationID
+	}
+}
+
+// WithToken sets the interaction token.
+func WithToken(token string) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.Token = token
+	}
+}
+
+// WithGuild sets the guild the interaction was created in.
+func WithGuild(guildID snowflake.ID) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.GuildID = &guildID
+	}
+}
+
+// WithDM makes the interaction a DM interaction without guild and member.
+func WithDM() InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.GuildID = nil
+	}
+}
+
+// WithChannel sets the channel the interaction was created in.
+func WithChannel(channelID snowflake.ID) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.ChannelID = channelID
+	}
+}
+
+// WithUser sets the user who created the interaction.
+func WithUser(user discord.User) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.User = user
+	}
+}
+
+// WithRoles sets the role IDs of the member who created the interaction.
+func WithRoles(roleIDs ...snowflake.ID) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.Roles = roleIDs
+	}
+}
+
+// WithPermissions sets the channel permissions of the member who created the interaction.
+func WithPermissions(permissions discord.Permissions) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.Permissions = permissions
+	}
+}
+
+// WithAppPermissions sets the channel permissions of the bot.
+func WithAppPermissions(permissions discord.Permissions) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.AppPermissions = permissions
+	}
+}
+
+// WithLocale sets the locale of the user who created the interaction.
+func WithLocale(locale discord.Locale) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.Locale = locale
+	}
+}
+
+// WithGuildLocale sets the preferred locale of the guild.
+func WithGuildLocale(locale discord.Locale) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.GuildLocale = &locale
+	}
+}
+
+// WithMessage sets the message a component is attached to.
+func WithMessage(message discord.Message) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.Message = &message
+	}
+}
+
+// WithResolved adds the given resolved data. Entities passed to WithOption are resolved automatically.
+func WithResolved(resolved discord.ResolvedData) InteractionOpt {
+	return func(config *InteractionConfig) {
+		addResolved(&config.Resolved, resolved)
+	}
+}
+
+// WithOption adds a command option with the given name and value. The option type is derived from the value:
+//   - string, int, float64 and bool become string, integer, number and boolean options
+//   - discord.User, discord.Member and discord.ResolvedMember become user options
+//   - discord.Role becomes a role option
+//   - discord.ResolvedChannel becomes a channel option
+//   - discord.Attachment becomes an attachment option
+//
+// Entities are added to the resolved data. Use WithTypedOption for mentionable options. WithOption panics for other types.
+func WithOption(name string, value any) InteractionOpt {
+	return func(config *InteractionConfig) {
+		var (
+			optionType discord.ApplicationCommandOptionType
+			resolved   discord.ResolvedData
+		)
+		switch v := value.(type) {
+		case string:
+			optionType = discord.ApplicationCommandOptionTypeString
+		case int, int64:
+			optionType = discord.ApplicationCommandOptionTypeInt
+		case float64:
+			optionType = discord.ApplicationCommandOptionTypeFloat
+		case bool:
+			optionType = discord.ApplicationCommandOptionTypeBool
+		case discord.User:
+			optionType, value = discord.ApplicationCommandOptionTypeUser, v.ID
+			resolved.Users = map[snowflake.ID]discord.User{v.ID: v}
+		case discord.Member:
+			optionType, value = discord.ApplicationCommandOptionTypeUser, v.User.ID
+			resolved.Users = map[snowflake.ID]discord.User{v.User.ID: v.User}
+			resolved.Members = map[snowflake.ID]discord.ResolvedMember{v.User.ID: {Member: v}}
+		case discord.ResolvedMember:
+			optionType, value = discord.ApplicationCommandOptionTypeUser, v.User.ID
+			resolved.Users = map[snowflake.ID]discord.User{v.User.ID: v.User}
+			resolved.Members = map[snowflake.ID]discord.ResolvedMember{v.User.ID: v}
+		case discord.Role:
+			optionType, value = discord.ApplicationCommandOptionTypeRole, v.ID
+			resolved.Roles = map[snowflake.ID]discord.Role{v.ID: v}
+		case discord.ResolvedChannel:
+			optionType, value = discord.ApplicationCommandOptionTypeChannel, v.ID
+			resolved.Channels = map[snowflake.ID]discord.ResolvedChannel{v.ID: v}
+		case discord.Attachment:
+			optionType, value = discord.ApplicationCommandOptionTypeAttachment, v.ID
+			resolved.Attachments = map[snowflake.ID]discord.Attachment{v.ID: v}
+		default:
+			panic("handlertest: unsupported option value type " + reflect.TypeOf(value).String())
+		}
+		addResolved(&config.Resolved, resolved)
+		config.options = append(config.options, option{name: name, optionType: optionType, value: value})
+	}
+}
+
+// WithTypedOption adds a command option with the given type and raw value, for example the ID of a mentionable.
+// The resolved data of entities must be added with WithResolved.
+func WithTypedOption(name string, optionType discord.ApplicationCommandOptionType, value any) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.options = append(config.options, option{name: name, optionType: optionType, value: value})
+	}
+}
+
+// WithModalValue adds a text input with the given custom id and value to a modal submit interaction.
+func WithModalValue(customID string, value string) InteractionOpt {
+	return func(config *InteractionConfig) {
+		config.modalItems = append(config.modalItems, modalItem{customID: customID, value: value})
+	}
+}
+
+var idIncrement atomic.Uint64
+
+// newID returns a unique snowflake.ID for the current time.
+func newID() snowflake.ID {
+	return snowflake.New(time.Now()) | snowflake.ID(idIncrement.Add(1)&0xFFF)
+}
+
+func addResolved(dst *discord.ResolvedData, src discord.ResolvedData) {
+	dst.Users = mergeMap(dst.Users, src.Users)
+	dst.Members = mergeMap(dst.Members, src.Members)
+	dst.Roles = mergeMap(dst.Roles, src.Roles)
+	dst.Channels = mergeMap(dst.Channels, src.Channels)
+	dst.Attachments = mergeMap(dst.Attachments, src.Attachments)
+}
+
+func mergeMap[V any](dst map[snowflake.ID]V, src map[snowflake.ID]V) map[snowflake.ID]V {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(map[snowflake.ID]V, len(src))
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
@@ -0,0 +1,274 @@
+package handlertest
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// ErrUnexpectedRequest is returned for all rest requests of the Recorder's bot.Client which are not interaction responses or follow-up messages.
+var ErrUnexpectedRequest = errors.New("handlertest: unexpected rest request")
+
+// NewRecorder returns a new Recorder with a bot.Client which records all interaction responses and follow-up messages.
+func NewRecorder() *Recorder {
+	r := &Recorder{}
+	client, err := disgo.New(Token(DefaultApplicationID),
+		bot.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		bot.WithRest(&recorderRest{Rest: rest.New(erroringClient{}), recorder: r}),
+	)
+	if err != nil {
+		panic("handlertest: failed to create client: " + err.Error())
+	}
+	r.client = client
+	return r
+}
+
+// Token returns a fake bot token for the given application id.
+func Token(applicationID snowflake.ID) string {
+	return base64.RawStdEncoding.EncodeToString([]byte(applicationID.String())) + ".handlertest"
+}
+
+// FollowupUpdate is an update of a follow-up message recorded by the Recorder.
+type FollowupUpdate struct {
+	MessageID snowflake.ID
+	Update    discord.MessageUpdate
+}
+
+// Recorder records the interaction responses, response updates and follow-up messages sent by handlers.
+type Recorder struct {
+	client bot.Client
+
+	mu               sync.Mutex
+	responses        []discord.InteractionResponse
+	responseUpdates  []discord.MessageUpdate
+	responseDeleted  bool
+	followups        []discord.MessageCreate
+	followupIDs      []snowflake.ID
+	followupUpdates  []FollowupUpdate
+	deletedFollowups []snowflake.ID
+}
+
+// Client returns the bot.Client of the Recorder.
+func (r *Recorder) Client() bot.Client {
+	return r.client
+}
+
+// Respond records the interaction response. It can be used as events.InteractionResponderFunc.
+func (r *Recorder) Respond(responseType discord.InteractionResponseType, data discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, discord.InteractionResponse{
+		Type: responseType,
+		Data: data,
+	})
+	return nil
+}
+
+// Event returns a new events.InteractionCreate for the interaction which responds to the Recorder.
+func (r *Recorder) Event(interaction discord.Interaction) *events.InteractionCreate {
+	return &events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(r.client, 0, 0),
+		Interaction:  interaction,
+		Respond:      r.Respond,
+	}
+}
+
+// Serve passes a new events.InteractionCreate for the interaction to the listener, for example a handler.Mux.
+func (r *Recorder) Serve(listener bot.EventListener, interaction discord.Interaction) {
+	listener.OnEvent(r.Event(interaction))
+}
+
+// Responses returns all recorded interaction responses.
+func (r *Recorder) Responses() []discord.InteractionResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]discord.InteractionResponse(nil), r.responses...)
+}
+
+// Response returns the first recorded interaction response.
+func (r *Recorder) Response() (discord.InteractionResponse, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.responses) == 0 {
+		return discord.InteractionResponse{}, false
+	}
+	return r.responses[0], true
+}
+
+// ResponseUpdates returns all recorded updates of the original interaction response.
+func (r *Recorder) ResponseUpdates() []discord.MessageUpdate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]discord.MessageUpdate(nil), r.responseUpdates...)
+}
+
+// ResponseDeleted returns whether the original interaction response was deleted.
+func (r *Recorder) ResponseDeleted() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.responseDeleted
+}
+
+// Followups returns all recorded follow-up messages.
+func (r *Recorder) Followups() []discord.MessageCreate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]discord.MessageCreate(nil), r.followups...)
+}
+
+// FollowupUpdates returns all recorded updates of follow-up messages.
+func (r *Recorder) FollowupUpdates() []FollowupUpdate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]FollowupUpdate(nil), r.followupUpdates...)
+}
+
+// DeletedFollowups returns the ids of all deleted follow-up messages.
+func (r *Recorder) DeletedFollowups() []snowflake.ID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]snowflake.ID(nil), r.deletedFollowups...)
+}
+
+// Reset removes everything recorded.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = nil
+	r.responseUpdates = nil
+	r.responseDeleted = false
+	r.followups = nil
+	r.followupIDs = nil
+	r.followupUpdates = nil
+	r.deletedFollowups = nil
+}
+
+func (r *Recorder) message(applicationID snowflake.ID, id snowflake.ID, content string) *discord.Message {
+	return &discord.Message{
+		ID:        id,
+		Content:   content,
+		Author:    discord.User{ID: applicationID, Username: "bot", Bot: true},
+		CreatedAt: time.Now(),
+		Type:      discord.MessageTypeDefault,
+	}
+}
+
+var _ rest.Rest = (*recorderRest)(nil)
+
+type recorderRest struct {
+	rest.Rest
+	recorder *Recorder
+}
+
+func (s *recorderRest) GetInteractionResponse(applicationID snowflake.ID, _ string, _ ...rest.RequestOpt) (*discord.Message, error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	var content string
+	if len(s.recorder.responses) > 0 {
+		if data, ok := s.recorder.responses[0].Data.(discord.MessageCreate); ok {
+			content = data.Content
+		}
+	}
+	if n := len(s.recorder.responseUpdates); n > 0 && s.recorder.responseUpdates[n-1].Content != nil {
+		content = *s.recorder.responseUpdates[n-1].Content
+	}
+	return s.recorder.message(applicationID, 0, content), nil
+}
+
+func (s *recorderRest) CreateInteractionResponse(_ snowflake.ID, _ string, response discord.InteractionResponse, _ ...rest.RequestOpt) error {
+	return s.recorder.Respond(response.Type, response.Data)
+}
+
+func (s *recorderRest) CreateInteractionResponseWithCallback(interactionID snowflake.ID, _ string, response discord.InteractionResponse, _ ...rest.RequestOpt) (*discord.InteractionCallbackResponse, error) {
+	if err := s.recorder.Respond(response.Type, response.Data); err != nil {
+		return nil, err
+	}
+	return &discord.InteractionCallbackResponse{
+		Interaction: discord.InteractionCallback{ID: interactionID},
+	}, nil
+}
+
+func (s *recorderRest) UpdateInteractionResponse(applicationID snowflake.ID, _ string, messageUpdate discord.MessageUpdate, _ ...rest.RequestOpt) (*discord.Message, error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.responseUpdates = append(s.recorder.responseUpdates, messageUpdate)
+	var content string
+	if messageUpdate.Content != nil {
+		content = *messageUpdate.Content
+	}
+	return s.recorder.message(applicationID, 0, content), nil
+}
+
+func (s *recorderRest) DeleteInteractionResponse(_ snowflake.ID, _ string, _ ...rest.RequestOpt) error {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.responseDeleted = true
+	return nil
+}
+
+func (s *recorderRest) GetFollowupMessage(applicationID snowflake.ID, _ string, messageID snowflake.ID, _ ...rest.RequestOpt) (*discord.Message, error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	for i, id := range s.recorder.followupIDs {
+		if id == messageID {
+			return s.recorder.message(applicationID, id, s.recorder.followups[i].Content), nil
+		}
+	}
+	return nil, fmt.Errorf("handlertest: unknown follow-up message %s", messageID)
+}
+
+func (s *recorderRest) CreateFollowupMessage(applicationID snowflake.ID, _ string, messageCreate discord.MessageCreate, _ ...rest.RequestOpt) (*discord.Message, error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	id := newID()
+	s.recorder.followups = append(s.recorder.followups, messageCreate)
+	s.recorder.followupIDs = append(s.recorder.followupIDs, id)
+	return s.recorder.message(applicationID, id, messageCreate.Content), nil
+}
+
+func (s *recorderRest) UpdateFollowupMessage(applicationID snowflake.ID, _ string, messageID snowflake.ID, messageUpdate discord.MessageUpdate, _ ...rest.RequestOpt) (*discord.Message, error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.followupUpdates = append(s.recorder.followupUpdates, FollowupUpdate{MessageID: messageID, Update: messageUpdate})
+	var content string
+	if messageUpdate.Content != nil {
+		content = *messageUpdate.Content
+	}
+	return s.recorder.message(applicationID, messageID, content), nil
+}
+
+func (s *recorderRest) DeleteFollowupMessage(_ snowflake.ID, _ string, messageID snowflake.ID, _ ...rest.RequestOpt) error {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.deletedFollowups = append(s.recorder.deletedFollowups, messageID)
+	return nil
+}
+
+var _ rest.Client = erroringClient{}
+
+// erroringClient is a rest.Client which fails all requests.
+type erroringClient struct{}
+
+func (erroringClient) HTTPClient() *http.Client { return http.DefaultClient }
+
+func (erroringClient) RateLimiter() rest.RateLimiter { return nil }
+
+func (erroringClient) Close(context.Context) {}
+
+func (erroringClient) Do(_ *rest.CompiledEndpoint, _ any, _ any, _ ...rest.RequestOpt) error {
+	return ErrUnexpectedRequest
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -14,6 +15,49 @@ type InteractionEvent struct {
 	*events.InteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// State is the restored State of component and modal interactions or nil if the custom id has no state. See Mux.State.
+	State *State
+
+	// route is shared with copies of the InteractionEvent, so middlewares see the full route after the next Handler returned
+	route *string
+	ack   *acknowledgement
+}
+
+// Route returns the patterns of the routers and the handler which matched the interaction joined together, for example /settings/{key}.
+// Middlewares only see the pattern of the routers they are registered on until the next Handler returned.
+func (e *InteractionEvent) Route() string {
+	if e.route == nil {
+		return ""
+	}
+	return *e.route
+}
+
+func (e *InteractionEvent) appendRoute(pattern string) {
+	if e.route == nil {
+		e.route = new(string)
+	}
+	*e.route += pattern
+}
+
+// Respond responds to the interaction with the given type and data.
+// Like all other requests of the InteractionEvent, it uses the Ctx unless another context.Context is passed with rest.WithCtx.
+//
+// For interactions handled by a Mux, Respond tracks whether the interaction was acknowledged and redirects responses to acknowledged interactions:
+// deferring again does nothing, the first message after DeferCreateMessage updates the deferred message,
+// other messages are sent as follow-up messages and message updates after a deferred response update the original response.
+// Other responses to acknowledged interactions return discord.ErrInteractionAlreadyReplied.
+func (e *InteractionEvent) Respond(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+	return e.ack.send(e, responseType, data, opts)
+}
+
+// Acknowledged returns whether the interaction was already responded to or deferred.
+func (e *InteractionEvent) Acknowledged() bool {
+	return e.ack.acknowledged()
+}
+
+// ExpiresAt returns the time until the interaction has to be acknowledged or, if it was acknowledged, until follow-up messages can be sent.
+func (e *InteractionEvent) ExpiresAt() time.Time {
+	return expiresAt(e.ID(), e.Acknowledged())
 }
 
 // CreateMessage responds to the interaction with a new message.
@@ -61,29 +105,37 @@ func (e *InteractionEvent) AutocompleteResult(choices []discord.AutocompleteChoi
 }
 
 func (e *InteractionEvent) GetInteractionResponse(opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().GetInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest().GetInteractionResponse(e.ApplicationID(), e.Token(), requestOpts(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) UpdateInteractionResponse(messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, opts...)
+	return e.Client().Rest().UpdateInteractionResponse(e.ApplicationID(), e.Token(), messageUpdate, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) DeleteInteractionResponse(opts ...rest.RequestOpt) error {
-	return e.Client().Rest().DeleteInteractionResponse(e.ApplicationID(), e.Token(), opts...)
+	return e.Client().Rest().DeleteInteractionResponse(e.ApplicationID(), e.Token(), requestOpts(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) GetFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest().GetFollowupMessage(e.ApplicationID(), e.Token(), messageID, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) CreateFollowupMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, opts...)
+	return e.Client().Rest().CreateFollowupMessage(e.ApplicationID(), e.Token(), messageCreate, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) UpdateFollowupMessage(messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return e.Client().Rest().UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, opts...)
+	return e.Client().Rest().UpdateFollowupMessage(e.ApplicationID(), e.Token(), messageID, messageUpdate, requestOpts(e.Ctx, opts)...)
 }
 
 func (e *InteractionEvent) DeleteFollowupMessage(messageID snowflake.ID, opts ...rest.RequestOpt) error {
-	return e.Client().Rest().DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, opts...)
+	return e.Client().Rest().DeleteFollowupMessage(e.ApplicationID(), e.Token(), messageID, requestOpts(e.Ctx, opts)...)
+}
+
+// requestOpts prepends rest.WithCtx with the given context.Context, so it can still be overridden by the caller.
+func requestOpts(ctx context.Context, opts []rest.RequestOpt) []rest.RequestOpt {
+	if ctx == nil {
+		return opts
+	}
+	return append([]rest.RequestOpt{rest.WithCtx(ctx)}, opts...)
 }
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// AutoDefer is a middleware which defers the interaction if the next handler has not responded within the threshold, 2.5 seconds by default.
+// The threshold is measured from the creation of the interaction, so time spent before the middleware ran, for example in gateway or rest latency, counts towards it.
+// Components are deferred with handler.InteractionEvent.DeferUpdateMessage and commands and modals with handler.InteractionEvent.DeferCreateMessage.
+// Once deferred, handler.InteractionEvent.Respond routes the eventual response of the handler to UpdateInteractionResponse or a follow-up message,
+// so handlers can respond the same way no matter whether they were fast or slow. Handlers which might be deferred can't respond with a modal.
+// Autocomplete interactions and interactions which were already acknowledged are passed to the next handler unchanged.
+func AutoDefer(opts ...AutoDeferConfigOpt) handler.Middleware {
+	cfg := DefaultAutoDeferConfig()
+	cfg.Apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			if event.Type() == discord.InteractionTypeAutocomplete || event.Acknowledged() {
+				return next(event)
+			}
+
+			done := make(chan struct{})
+			deferred := make(chan error, 1)
+			go func() {
+				timer := time.NewTimer(max(time.Until(event.ID().Time().Add(cfg.Threshold)), 0))
+				defer timer.Stop()
+				select {
+				case <-done:
+					deferred <- nil
+				case <-timer.C:
+					deferred <- autoDefer(event, cfg.Ephemeral)
+				}
+			}()
+
+			err := next(event)
+			close(done)
+			if deferErr := <-deferred; deferErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to defer interaction: %w", deferErr))
+			}
+			return err
+		}
+	}
+}
+
+func autoDefer(event *handler.InteractionEvent, ephemeral bool) error {
+	// deferring an interaction the handler responded to in the meantime does nothing
+	if event.Type() == discord.InteractionTypeComponent {
+		return event.DeferUpdateMessage()
+	}
+	return event.DeferCreateMessage(ephemeral)
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"time"
+)
+
+// DefaultAutoDeferConfig returns the default AutoDeferConfig.
+func DefaultAutoDeferConfig() *AutoDeferConfig {
+	return &AutoDeferConfig{
+		Threshold: 2500 * time.Millisecond,
+	}
+}
+
+// AutoDeferConfig is the configuration of the AutoDefer middleware.
+type AutoDeferConfig struct {
+	// Threshold is the time after the creation of the interaction until it is deferred if the handler has not responded.
+	Threshold time.Duration
+	// Ephemeral defers commands and modals with an ephemeral "bot is thinking..." message.
+	Ephemeral bool
+}
+
+// AutoDeferConfigOpt is a type alias for a function that takes a AutoDeferConfig and is used to configure your AutoDefer middleware.
+type AutoDeferConfigOpt func(config *AutoDeferConfig)
+
+// Apply applies the given AutoDeferConfigOpt(s) to the AutoDeferConfig.
+func (c *AutoDeferConfig) Apply(opts []AutoDeferConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithAutoDeferThreshold sets the time after the creation of the interaction until it is deferred if the handler has not responded.
+// It must be lower than handler.InitialResponseTimeout.
+func WithAutoDeferThreshold(threshold time.Duration) AutoDeferConfigOpt {
+	return func(config *AutoDeferConfig) {
+		config.Threshold = threshold
+	}
+}
+
+// WithAutoDeferEphemeral sets whether commands and modals are deferred with an ephemeral "bot is thinking..." message.
+func WithAutoDeferEphemeral(ephemeral bool) AutoDeferConfigOpt {
+	return func(config *AutoDeferConfig) {
+		config.Ephemeral = ephemeral
+	}
+}
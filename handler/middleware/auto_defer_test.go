@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+func TestAutoDefer(t *testing.T) {
+	mux := handler.New()
+	mux.Use(AutoDefer(WithAutoDeferThreshold(20*time.Millisecond), WithAutoDeferEphemeral(true)))
+	mux.SlashCommand("/fast", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: "fast"})
+	})
+	mux.SlashCommand("/slow", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		time.Sleep(100 * time.Millisecond)
+		assert.True(t, e.Acknowledged())
+		return e.CreateMessage(discord.MessageCreate{Content: "slow"})
+	})
+	mux.ButtonComponent("/slow", func(data discord.ButtonInteractionData, e *handler.ComponentEvent) error {
+		time.Sleep(100 * time.Millisecond)
+		return e.UpdateMessage(discord.NewMessageUpdateBuilder().SetContent("updated").Build())
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/fast"))
+	responses := rec.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, discord.InteractionResponseTypeCreateMessage, responses[0].Type)
+	time.Sleep(40 * time.Millisecond)
+	assert.Len(t, rec.Responses(), 1, "fast handlers should not be deferred")
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/slow"))
+	responses = rec.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, responses[0].Type)
+	assert.Equal(t, discord.MessageCreate{Flags: discord.MessageFlagEphemeral}, responses[0].Data)
+	updates := rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "slow", *updates[0].Content)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewButtonInteraction("/slow"))
+	responses = rec.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredUpdateMessage, responses[0].Type)
+	updates = rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "updated", *updates[0].Content)
+}
+
+func TestAutoDeferFromCreation(t *testing.T) {
+	mux := handler.New()
+	mux.Use(AutoDefer(WithAutoDeferThreshold(time.Second + 20*time.Millisecond)))
+	mux.SlashCommand("/slow", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		time.Sleep(100 * time.Millisecond)
+		return e.CreateMessage(discord.MessageCreate{Content: "slow"})
+	})
+
+	rec := handlertest.NewRecorder()
+	createdAt := time.Now().Add(-time.Second)
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/slow", handlertest.WithInteractionID(snowflake.New(createdAt))))
+	responses := rec.Responses()
+	require.Len(t, responses, 1)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, responses[0].Type, "the threshold should count from the creation of the interaction")
+}
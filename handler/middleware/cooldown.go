@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// CooldownScope decides who shares a cooldown bucket.
+type CooldownScope int
+
+const (
+	// CooldownScopeUser gives every user their own bucket.
+	CooldownScopeUser CooldownScope = iota
+	// CooldownScopeGuild shares a bucket per guild. Interactions outside of guilds use the bucket of their channel.
+	CooldownScopeGuild
+	// CooldownScopeChannel shares a bucket per channel.
+	CooldownScopeChannel
+	// CooldownScopeGlobal shares a single bucket between everyone.
+	CooldownScopeGlobal
+)
+
+func (s CooldownScope) String() string {
+	switch s {
+	case CooldownScopeUser:
+		return "user"
+	case CooldownScopeGuild:
+		return "guild"
+	case CooldownScopeChannel:
+		return "channel"
+	case CooldownScopeGlobal:
+		return "global"
+	}
+	return "unknown"
+}
+
+// CooldownStore stores the token buckets of the Cooldown middleware.
+// All methods are called from multiple goroutines.
+type CooldownStore interface {
+	// Take takes a token from the bucket with the key. The bucket holds up to uses tokens and refills all of them over the window.
+	// It returns zero if a token was taken or else how long to wait until the next token is available.
+	Take(ctx context.Context, key string, uses int, window time.Duration) (time.Duration, error)
+}
+
+var _ CooldownStore = (*memoryCooldownStore)(nil)
+
+// NewMemoryCooldownStore returns a CooldownStore which keeps all buckets in memory.
+func NewMemoryCooldownStore() CooldownStore {
+	return &memoryCooldownStore{
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+type memoryCooldownStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func (s *memoryCooldownStore) Take(_ context.Context, key string, uses int, window time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	// remove full buckets at most once a minute
+	if now.Sub(s.lastSweep) > time.Minute {
+		s.lastSweep = now
+		for bucketKey, bucket := range s.buckets {
+			if now.After(bucket.full) {
+				delete(s.buckets, bucketKey)
+			}
+		}
+	}
+
+	rate := float64(uses) / float64(window)
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(uses), updated: now}
+		s.buckets[key] = bucket
+	}
+	bucket.tokens = min(float64(uses), bucket.tokens+float64(now.Sub(bucket.updated))*rate)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / rate), nil
+	}
+	bucket.tokens--
+	bucket.full = now.Add(time.Duration((float64(uses) - bucket.tokens) / rate))
+	return 0, nil
+}
+
+// Cooldown is a middleware which allows the given number of uses per window with token bucket semantics: uses can be spent at once and refill evenly over the window.
+// Rate limited users receive a localized ephemeral reply and the next handler is not called.
+// By default, every user has their own bucket per middleware. Use WithCooldownScope to share buckets per guild, channel or globally and WithCooldownVars to split them by route variables.
+//
+// Like all middlewares, Cooldown runs before the variables of handler patterns are parsed, so WithCooldownVars only sees the variables of router patterns:
+// use router.Route("/vote/{choice}", ...) with the middleware instead of router.Component("/vote/{choice}", ...).
+//
+// Cooldown panics if uses is less than 1 or window is not positive.
+func Cooldown(uses int, window time.Duration, opts ...CooldownConfigOpt) handler.Middleware {
+	if uses < 1 || window <= 0 {
+		panic(fmt.Sprintf("cooldown needs at least 1 use and a positive window, got %d uses per %s", uses, window))
+	}
+	cfg := DefaultCooldownConfig()
+	cfg.Apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			retryAfter, err := cfg.Store.Take(event.Ctx, cooldownKey(cfg, event), uses, window)
+			if err != nil {
+				return fmt.Errorf("failed to take cooldown token: %w", err)
+			}
+			if retryAfter <= 0 {
+				return next(event)
+			}
+			if cfg.OnRateLimited != nil {
+				return cfg.OnRateLimited(event, retryAfter)
+			}
+			return cooldownReply(cfg, event, retryAfter)
+		}
+	}
+}
+
+func cooldownKey(cfg *CooldownConfig, event *handler.InteractionEvent) string {
+	var id string
+	switch cfg.Scope {
+	case CooldownScopeUser:
+		id = event.User().ID.String()
+	case CooldownScopeGuild:
+		if guildID := event.GuildID(); guildID != nil {
+			id = guildID.String()
+		} else {
+			id = event.Channel().ID().String()
+		}
+	case CooldownScopeChannel:
+		id = event.Channel().ID().String()
+	}
+
+	key := cfg.Name + ":" + cfg.Scope.String() + ":" + id
+	if cfg.Bucket != nil {
+		key += ":" + cfg.Bucket(event)
+	}
+	return key
+}
+
+func cooldownReply(cfg *CooldownConfig, event *handler.InteractionEvent, retryAfter time.Duration) error {
+	if event.Type() == discord.InteractionTypeAutocomplete {
+		return event.AutocompleteResult(nil)
+	}
+
+	message, ok := cfg.Messages[event.Locale()]
+	if !ok {
+		message = cfg.Messages[discord.LocaleEnglishUS]
+	}
+	// round up, so users don't retry a moment too early
+	retryAt := time.Now().Add(retryAfter + time.Second - 1).Unix()
+	message = strings.ReplaceAll(message, "{retry_after}", "<t:"+strconv.FormatInt(retryAt, 10)+":R>")
+
+	return event.CreateMessage(discord.MessageCreate{
+		Content: message,
+		Flags:   discord.MessageFlagEphemeral,
+	})
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// defaultCooldownMessage is the discord.LocaleEnglishUS message, which is used as fallback if no other message for discord.LocaleEnglishUS is configured.
+const defaultCooldownMessage = "You are on cooldown. Try again {retry_after}."
+
+// DefaultCooldownConfig returns the default CooldownConfig.
+func DefaultCooldownConfig() *CooldownConfig {
+	return &CooldownConfig{
+		Scope: CooldownScopeUser,
+		Messages: map[discord.Locale]string{
+			discord.LocaleEnglishUS: defaultCooldownMessage,
+			discord.LocaleGerman:    "Du bist im Cooldown. Versuche es {retry_after} erneut.",
+			discord.LocaleFrench:    "Tu es en cooldown. Réessaie {retry_after}.",
+			discord.LocaleSpanishES: "Estás en enfriamiento. Inténtalo de nuevo {retry_after}.",
+		},
+	}
+}
+
+// CooldownConfig is the configuration of the Cooldown middleware.
+type CooldownConfig struct {
+	// Name is prepended to all bucket keys, which is required when multiple Cooldown middlewares share a CooldownStore.
+	Name string
+	// Scope decides who shares a bucket.
+	Scope CooldownScope
+	// Bucket returns an additional key which splits the buckets of the Scope, for example by route variables.
+	Bucket func(event *handler.InteractionEvent) string
+	// Store stores the buckets. Defaults to a new NewMemoryCooldownStore per middleware.
+	Store CooldownStore
+	// Messages are the replies per discord.Locale to rate limited users. {retry_after} is replaced with a relative timestamp.
+	// Messages falls back to discord.LocaleEnglishUS for missing locales, which falls back to the default message if it is missing.
+	Messages map[discord.Locale]string
+	// OnRateLimited replaces the reply to rate limited users.
+	OnRateLimited func(event *handler.InteractionEvent, retryAfter time.Duration) error
+}
+
+// CooldownConfigOpt is a type alias for a function that takes a CooldownConfig and is used to configure your Cooldown middleware.
+type CooldownConfigOpt func(config *CooldownConfig)
+
+// Apply applies the given CooldownConfigOpt(s) to the CooldownConfig.
+func (c *CooldownConfig) Apply(opts []CooldownConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryCooldownStore()
+	}
+	if _, ok := c.Messages[discord.LocaleEnglishUS]; !ok {
+		if c.Messages == nil {
+			c.Messages = map[discord.Locale]string{}
+		}
+		c.Messages[discord.LocaleEnglishUS] = defaultCooldownMessage
+	}
+}
+
+// WithCooldownName sets the name which is prepended to all bucket keys.
+func WithCooldownName(name string) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Name = name
+	}
+}
+
+// WithCooldownScope sets the CooldownScope which decides who shares a bucket.
+func WithCooldownScope(scope CooldownScope) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Scope = scope
+	}
+}
+
+// WithCooldownBucket sets a function which returns an additional key to split the buckets of the CooldownScope.
+func WithCooldownBucket(bucket func(event *handler.InteractionEvent) string) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Bucket = bucket
+	}
+}
+
+// WithCooldownVars splits the buckets of the CooldownScope by the values of the given route variables.
+//
+// The variables must be part of the pattern of the router the middleware is used on or of one of its parent routers.
+// Variables of handler patterns are parsed after the middlewares ran and are always empty here, so all values would share one bucket.
+func WithCooldownVars(names ...string) CooldownConfigOpt {
+	return WithCooldownBucket(func(event *handler.InteractionEvent) string {
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			values = append(values, event.Vars[name])
+		}
+		return strings.Join(values, "/")
+	})
+}
+
+// WithCooldownStore sets the CooldownStore which stores the buckets.
+func WithCooldownStore(store CooldownStore) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Store = store
+	}
+}
+
+// WithCooldownMessages replaces the replies per discord.Locale to rate limited users. {retry_after} is replaced with a relative timestamp.
+// Without a discord.LocaleEnglishUS message, the default one is used as fallback.
+func WithCooldownMessages(messages map[discord.Locale]string) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Messages = maps.Clone(messages)
+	}
+}
+
+// WithCooldownMessage sets the reply for the given discord.Locale to rate limited users. {retry_after} is replaced with a relative timestamp.
+func WithCooldownMessage(locale discord.Locale, message string) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		if config.Messages == nil {
+			config.Messages = map[discord.Locale]string{}
+		}
+		config.Messages[locale] = message
+	}
+}
+
+// WithCooldownRateLimited sets a function which replaces the reply to rate limited users.
+func WithCooldownRateLimited(onRateLimited func(event *handler.InteractionEvent, retryAfter time.Duration) error) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.OnRateLimited = onRateLimited
+	}
+}
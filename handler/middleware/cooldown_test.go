@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+func TestMemoryCooldownStore(t *testing.T) {
+	store := NewMemoryCooldownStore()
+	for i := 0; i < 2; i++ {
+		retryAfter, err := store.Take(context.Background(), "key", 2, 100*time.Millisecond)
+		require.NoError(t, err)
+		assert.Zero(t, retryAfter)
+	}
+	retryAfter, err := store.Take(context.Background(), "key", 2, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.InDelta(t, 50*time.Millisecond, retryAfter, float64(10*time.Millisecond), "one token refills every half window")
+
+	retryAfter, _ = store.Take(context.Background(), "other", 2, 100*time.Millisecond)
+	assert.Zero(t, retryAfter, "buckets should be independent")
+
+	time.Sleep(retryAfter + 60*time.Millisecond)
+	retryAfter, _ = store.Take(context.Background(), "key", 2, 100*time.Millisecond)
+	assert.Zero(t, retryAfter)
+}
+
+func TestCooldownInvalid(t *testing.T) {
+	assert.Panics(t, func() { Cooldown(0, time.Minute) })
+	assert.Panics(t, func() { Cooldown(1, 0) })
+}
+
+func TestCooldownConfigMessages(t *testing.T) {
+	cfg := DefaultCooldownConfig()
+	cfg.Apply([]CooldownConfigOpt{WithCooldownMessages(map[discord.Locale]string{discord.LocaleGerman: "Warte {retry_after}."})})
+	assert.Equal(t, map[discord.Locale]string{
+		discord.LocaleGerman:    "Warte {retry_after}.",
+		discord.LocaleEnglishUS: defaultCooldownMessage,
+	}, cfg.Messages, "the default message should be the fallback")
+
+	cfg = DefaultCooldownConfig()
+	assert.NotPanics(t, func() {
+		cfg.Apply([]CooldownConfigOpt{WithCooldownMessages(nil), WithCooldownMessage(discord.LocaleEnglishUS, "Wait {retry_after}.")})
+	})
+	assert.Equal(t, map[discord.Locale]string{discord.LocaleEnglishUS: "Wait {retry_after}."}, cfg.Messages)
+}
+
+func TestCooldown(t *testing.T) {
+	var calls int
+	mux := handler.New()
+	mux.Route("/vote/{choice}", func(r handler.Router) {
+		r.Use(Cooldown(1, time.Hour, WithCooldownVars("choice")))
+		r.ButtonComponent("/", func(data discord.ButtonInteractionData, e *handler.ComponentEvent) error {
+			calls++
+			return e.DeferUpdateMessage()
+		})
+	})
+
+	rec := handlertest.NewRecorder()
+	content := func(customID string, opts ...handlertest.InteractionOpt) string {
+		rec.Reset()
+		rec.Serve(mux, handlertest.NewButtonInteraction(customID, opts...))
+		response, ok := rec.Response()
+		require.True(t, ok)
+		if message, ok := response.Data.(discord.MessageCreate); ok {
+			assert.Equal(t, discord.MessageFlagEphemeral, message.Flags)
+			return message.Content
+		}
+		return ""
+	}
+
+	assert.Empty(t, content("/vote/yes"))
+	assert.True(t, strings.HasPrefix(content("/vote/yes"), "You are on cooldown. Try again <t:"))
+	assert.True(t, strings.HasPrefix(content("/vote/yes", handlertest.WithLocale(discord.LocaleGerman)), "Du bist im Cooldown."))
+	assert.Empty(t, content("/vote/no"), "route vars should split buckets")
+	assert.Empty(t, content("/vote/yes", handlertest.WithUser(discord.User{ID: 1300000000000000009})), "users should have their own buckets")
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// UserError is an error whose message is shown to the user by the Errors middleware.
+type UserError struct {
+	// Message is shown to the user.
+	Message string
+	// Err is the optional underlying error. It is not shown to the user.
+	Err error
+}
+
+// NewUserError returns a new *UserError with the given message.
+func NewUserError(message string) *UserError {
+	return &UserError{Message: message}
+}
+
+// UserErrorf returns a new *UserError with the formatted message.
+func UserErrorf(format string, a ...any) *UserError {
+	return &UserError{Message: fmt.Sprintf(format, a...)}
+}
+
+// WrapUserError returns a new *UserError with the given message which wraps err.
+func WrapUserError(err error, message string) *UserError {
+	return &UserError{Message: message, Err: err}
+}
+
+func (e *UserError) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *UserError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is a middleware which replies to errors returned by the next handler with an ephemeral message.
+// A *UserError or an error configured with WithErrorMessage is shown to the user.
+// Other errors are logged with a correlation id, which is shown to the user instead of the error.
+// If the interaction was already acknowledged, for example by Defer, the message updates the deferred message or is sent as follow-up message.
+// Errors are not passed on to the handler.ErrorHandler unless the reply fails.
+func Errors(opts ...ErrorsConfigOpt) handler.Middleware {
+	cfg := DefaultErrorsConfig()
+	cfg.Apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			err := next(event)
+			if err == nil {
+				return nil
+			}
+
+			content, ok := userErrorMessage(cfg, err)
+			if !ok {
+				correlationID := newCorrelationID()
+				attrs := []any{
+					slog.String("correlation_id", correlationID),
+					slog.Int64("interaction_id", int64(event.ID())),
+					slog.Any("err", err),
+				}
+				var panicErr *PanicError
+				if errors.As(err, &panicErr) {
+					attrs = append(attrs, slog.String("stack", string(panicErr.Stack)))
+				}
+				event.Client().Logger().Error("error handling interaction", attrs...)
+				content = cfg.InternalMessage(event, correlationID)
+			}
+
+			if respondErr := replyError(event, content); respondErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to reply to error: %w", respondErr))
+			}
+			return nil
+		}
+	}
+}
+
+func userErrorMessage(cfg *ErrorsConfig, err error) (string, bool) {
+	var userErr *UserError
+	if errors.As(err, &userErr) {
+		return userErr.Message, true
+	}
+	for _, message := range cfg.Messages {
+		if errors.Is(err, message.Err) {
+			return message.Message, true
+		}
+	}
+	return "", false
+}
+
+func replyError(event *handler.InteractionEvent, content string) error {
+	if event.Type() == discord.InteractionTypeAutocomplete {
+		if event.Acknowledged() {
+			return nil
+		}
+		return event.AutocompleteResult(nil)
+	}
+
+	// InteractionEvent.Respond turns the message into an update of a deferred message or a follow-up message if the interaction was acknowledged
+	message := discord.MessageCreate{
+		Content: content,
+		Flags:   discord.MessageFlagEphemeral,
+	}
+	err := event.CreateMessage(message)
+	if errors.Is(err, discord.ErrInteractionAlreadyReplied) {
+		_, err = event.CreateFollowupMessage(message)
+	}
+	return err
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"github.com/disgoorg/disgo/handler"
+)
+
+// DefaultErrorsConfig returns the default ErrorsConfig.
+func DefaultErrorsConfig() *ErrorsConfig {
+	return &ErrorsConfig{
+		InternalMessage: func(_ *handler.InteractionEvent, correlationID string) string {
+			return "Something went wrong. Please try again later. (Error ID: `" + correlationID + "`)"
+		},
+	}
+}
+
+// ErrorsConfig is the configuration of the Errors middleware.
+type ErrorsConfig struct {
+	// Messages map errors to user-facing messages. They are matched in order with errors.Is.
+	Messages []ErrorMessage
+	// InternalMessage returns the content of the reply to errors which are not user-facing.
+	InternalMessage func(event *handler.InteractionEvent, correlationID string) string
+}
+
+// ErrorMessage maps an error to a user-facing message.
+type ErrorMessage struct {
+	Err     error
+	Message string
+}
+
+// ErrorsConfigOpt is a type alias for a function that takes a ErrorsConfig and is used to configure your Errors middleware.
+type ErrorsConfigOpt func(config *ErrorsConfig)
+
+// Apply applies the given ErrorsConfigOpt(s) to the ErrorsConfig.
+func (c *ErrorsConfig) Apply(opts []ErrorsConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithErrorMessage shows the message to the user for errors matching err with errors.Is, for example handler.ErrStateNotFound.
+func WithErrorMessage(err error, message string) ErrorsConfigOpt {
+	return func(config *ErrorsConfig) {
+		config.Messages = append(config.Messages, ErrorMessage{
+			Err:     err,
+			Message: message,
+		})
+	}
+}
+
+// WithInternalErrorMessage sets the function which returns the content of the reply to errors which are not user-facing.
+// The correlation id is logged together with the error.
+func WithInternalErrorMessage(message func(event *handler.InteractionEvent, correlationID string) string) ErrorsConfigOpt {
+	return func(config *ErrorsConfig) {
+		config.InternalMessage = message
+	}
+}
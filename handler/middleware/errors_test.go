@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+func TestRecover(t *testing.T) {
+	err := Recover(func(event *handler.InteractionEvent) error {
+		panic("boom")
+	})(&handler.InteractionEvent{})
+
+	var panicErr *PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func TestErrors(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	mux := handler.New()
+	mux.Use(Errors(WithErrorMessage(errNotFound, "Nothing found.")), Recover)
+	mux.SlashCommand("/user", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		return NewUserError("You can't do that.")
+	})
+	mux.SlashCommand("/mapped", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		return errors.Join(errors.New("lookup failed"), errNotFound)
+	})
+	mux.SlashCommand("/panic", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		panic("boom")
+	})
+	mux.SlashCommand("/deferred", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		if err := e.DeferCreateMessage(true); err != nil {
+			return err
+		}
+		return errors.New("internal")
+	})
+	mux.SlashCommand("/replied", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		if err := e.CreateMessage(discord.MessageCreate{Content: "working"}); err != nil {
+			return err
+		}
+		return NewUserError("You can't do that.")
+	})
+
+	rec := handlertest.NewRecorder()
+
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/user"))
+	response, ok := rec.Response()
+	require.True(t, ok)
+	assert.Equal(t, discord.MessageCreate{Content: "You can't do that.", Flags: discord.MessageFlagEphemeral}, response.Data)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/mapped"))
+	response, ok = rec.Response()
+	require.True(t, ok)
+	assert.Equal(t, "Nothing found.", response.Data.(discord.MessageCreate).Content)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/panic"))
+	response, ok = rec.Response()
+	require.True(t, ok)
+	assert.Regexp(t, "^Something went wrong\\. Please try again later\\. \\(Error ID: `[0-9a-f]{16}`\\)$", response.Data.(discord.MessageCreate).Content)
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/deferred"))
+	require.Len(t, rec.Responses(), 1)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, rec.Responses()[0].Type)
+	updates := rec.ResponseUpdates()
+	require.Len(t, updates, 1)
+	assert.Contains(t, *updates[0].Content, "Error ID")
+
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/replied"))
+	require.Len(t, rec.Responses(), 1)
+	followups := rec.Followups()
+	require.Len(t, followups, 1)
+	assert.Equal(t, "You can't do that.", followups[0].Content)
+	assert.Equal(t, discord.MessageFlagEphemeral, followups[0].Flags)
+}
+
+func TestErrorsAfterPublicDefer(t *testing.T) {
+	mux := handler.New()
+	mux.Use(Errors())
+	mux.Route("/defer", func(r handler.Router) {
+		r.Use(Defer(discord.InteractionTypeApplicationCommand, false, false))
+		r.SlashCommand("/", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+			return NewUserError("You can't do that.")
+		})
+	})
+	mux.Route("/auto", func(r handler.Router) {
+		r.Use(AutoDefer(WithAutoDeferThreshold(10 * time.Millisecond)))
+		r.SlashCommand("/", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+			time.Sleep(50 * time.Millisecond)
+			return NewUserError("You can't do that.")
+		})
+	})
+
+	rec := handlertest.NewRecorder()
+	for _, path := range []string{"/defer", "/auto"} {
+		rec.Reset()
+		rec.Serve(mux, handlertest.NewSlashCommandInteraction(path))
+		responses := rec.Responses()
+		require.Len(t, responses, 1, path)
+		assert.Equal(t, discord.InteractionResponseTypeDeferredCreateMessage, responses[0].Type, path)
+		assert.Empty(t, rec.ResponseUpdates(), "%s: the error must not replace the public deferred message", path)
+		followups := rec.Followups()
+		require.Len(t, followups, 1, path)
+		assert.Equal(t, discord.MessageCreate{Content: "You can't do that.", Flags: discord.MessageFlagEphemeral}, followups[0], path)
+	}
+}
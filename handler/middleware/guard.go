@@ -0,0 +1,233 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// GuardReason is the reason why a guard middleware denied an interaction.
+type GuardReason int
+
+const (
+	// GuardReasonMemberPermissions is used when the member is missing permissions.
+	GuardReasonMemberPermissions GuardReason = iota
+	// GuardReasonBotPermissions is used when the bot is missing permissions.
+	GuardReasonBotPermissions
+	// GuardReasonGuildOnly is used when the interaction is not in a guild.
+	GuardReasonGuildOnly
+	// GuardReasonDMOnly is used when the interaction is not in a direct message.
+	GuardReasonDMOnly
+	// GuardReasonOwnerOnly is used when the user is not an owner of the bot.
+	GuardReasonOwnerOnly
+	// GuardReasonRoles is used when the member doesn't have the required roles.
+	GuardReasonRoles
+)
+
+// GuardDenial describes why a guard middleware denied an interaction.
+type GuardDenial struct {
+	Reason GuardReason
+	// Permissions are the missing permissions for GuardReasonMemberPermissions and GuardReasonBotPermissions.
+	Permissions discord.Permissions
+	// RoleIDs are the required roles for GuardReasonRoles.
+	RoleIDs []snowflake.ID
+	// AllRoles is true if all RoleIDs are required instead of any of them.
+	AllRoles bool
+}
+
+// DefaultGuardMessage returns the default content of the ephemeral reply to denied interactions.
+func DefaultGuardMessage(_ *handler.InteractionEvent, denial GuardDenial) string {
+	switch denial.Reason {
+	case GuardReasonMemberPermissions:
+		return "You are missing the following permissions: " + permissionNames(denial.Permissions) + "."
+	case GuardReasonBotPermissions:
+		return "I am missing the following permissions: " + permissionNames(denial.Permissions) + "."
+	case GuardReasonGuildOnly:
+		return "This can only be used in servers."
+	case GuardReasonDMOnly:
+		return "This can only be used in direct messages."
+	case GuardReasonOwnerOnly:
+		return "This can only be used by the owners of the bot."
+	case GuardReasonRoles:
+		mentions := make([]string, 0, len(denial.RoleIDs))
+		for _, roleID := range denial.RoleIDs {
+			mentions = append(mentions, discord.RoleMention(roleID))
+		}
+		if denial.AllRoles {
+			return "You need all of the following roles: " + strings.Join(mentions, ", ") + "."
+		}
+		return "You need one of the following roles: " + strings.Join(mentions, ", ") + "."
+	}
+	return "You are not allowed to do this."
+}
+
+// permissionNames returns the names of the permissions in a stable order.
+func permissionNames(permissions discord.Permissions) string {
+	var names []string
+	for i := 0; i < 64; i++ {
+		if permission := discord.Permissions(1 << i); permissions.Has(permission) {
+			names = append(names, permission.String())
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Guard is a middleware which calls the next handler if check returns nil or else denies the interaction with the returned GuardDenial.
+// Denied interactions receive an ephemeral reply which can be customized with WithGuardMessage or WithGuardDenied.
+// It is the base of the other guard middlewares and can be used for custom checks.
+func Guard(check func(event *handler.InteractionEvent) (*GuardDenial, error), opts ...GuardConfigOpt) handler.Middleware {
+	cfg := DefaultGuardConfig()
+	cfg.Apply(opts)
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			denial, err := check(event)
+			if err != nil {
+				return err
+			}
+			if denial == nil {
+				return next(event)
+			}
+			if cfg.OnDenied != nil {
+				return cfg.OnDenied(event, *denial)
+			}
+			if event.Type() == discord.InteractionTypeAutocomplete {
+				return event.AutocompleteResult(nil)
+			}
+			return event.CreateMessage(discord.MessageCreate{
+				Content: cfg.Message(event, *denial),
+				Flags:   discord.MessageFlagEphemeral,
+			})
+		}
+	}
+}
+
+// RequirePermissions is a middleware which only allows members with all the given permissions in the channel of the interaction.
+// Interactions outside of guilds are denied with GuardReasonGuildOnly.
+func RequirePermissions(permissions discord.Permissions, opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(event *handler.InteractionEvent) (*GuardDenial, error) {
+		member := event.Member()
+		if member == nil {
+			return &GuardDenial{Reason: GuardReasonGuildOnly}, nil
+		}
+		if missing := permissions.Remove(member.Permissions); missing != discord.PermissionsNone {
+			return &GuardDenial{Reason: GuardReasonMemberPermissions, Permissions: missing}, nil
+		}
+		return nil, nil
+	}, opts...)
+}
+
+// RequireBotPermissions is a middleware which only allows interactions where the bot has all the given permissions in the channel of the interaction.
+func RequireBotPermissions(permissions discord.Permissions, opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(event *handler.InteractionEvent) (*GuardDenial, error) {
+		var appPermissions discord.Permissions
+		if p := event.AppPermissions(); p != nil {
+			appPermissions = *p
+		}
+		if missing := permissions.Remove(appPermissions); missing != discord.PermissionsNone {
+			return &GuardDenial{Reason: GuardReasonBotPermissions, Permissions: missing}, nil
+		}
+		return nil, nil
+	}, opts...)
+}
+
+// GuildOnly is a middleware which only allows interactions in guilds.
+func GuildOnly(opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(event *handler.InteractionEvent) (*GuardDenial, error) {
+		if event.GuildID() == nil {
+			return &GuardDenial{Reason: GuardReasonGuildOnly}, nil
+		}
+		return nil, nil
+	}, opts...)
+}
+
+// DMOnly is a middleware which only allows interactions in direct messages.
+func DMOnly(opts ...GuardConfigOpt) handler.Middleware {
+	return Guard(func(event *handler.InteractionEvent) (*GuardDenial, error) {
+		if event.GuildID() != nil {
+			return &GuardDenial{Reason: GuardReasonDMOnly}, nil
+		}
+		return nil, nil
+	}, opts...)
+}
+
+// OwnerOnly is a middleware which only allows the given users.
+// If no users are given, the owner or team members of the application are fetched once with rest.Applications.GetCurrentApplication.
+func OwnerOnly(ownerIDs []snowflake.ID, opts ...GuardConfigOpt) handler.Middleware {
+	var (
+		mu     sync.Mutex
+		loaded = len(ownerIDs) > 0
+	)
+	return Guard(func(event *handler.InteractionEvent) (*GuardDenial, error) {
+		mu.Lock()
+		if !loaded {
+			application, err := event.Client().Rest().GetCurrentApplication()
+			if err != nil {
+				mu.Unlock()
+				return nil, fmt.Errorf("failed to get application owners: %w", err)
+			}
+			ownerIDs = applicationOwnerIDs(*application)
+			loaded = true
+		}
+		owners := ownerIDs
+		mu.Unlock()
+
+		if !slices.Contains(owners, event.User().ID) {
+			return &GuardDenial{Reason: GuardReasonOwnerOnly}, nil
+		}
+		return nil, nil
+	}, opts...)
+}
+
+func applicationOwnerIDs(application discord.Application) []snowflake.ID {
+	if application.Team != nil {
+		ownerIDs := make([]snowflake.ID, 0, len(application.Team.Members))
+		for _, member := range application.Team.Members {
+			ownerIDs = append(ownerIDs, member.User.ID)
+		}
+		return ownerIDs
+	}
+	if application.Owner != nil {
+		return []snowflake.ID{application.Owner.ID}
+	}
+	return nil
+}
+
+// RequireAnyRole is a middleware which only allows members with at least one of the given roles.
+// Interactions outside of guilds are denied with GuardReasonGuildOnly.
+func RequireAnyRole(roleIDs []snowflake.ID, opts ...GuardConfigOpt) handler.Middleware {
+	return requireRoles(roleIDs, false, opts)
+}
+
+// RequireAllRoles is a middleware which only allows members with all the given roles.
+// Interactions outside of guilds are denied with GuardReasonGuildOnly.
+func RequireAllRoles(roleIDs []snowflake.ID, opts ...GuardConfigOpt) handler.Middleware {
+	return requireRoles(roleIDs, true, opts)
+}
+
+func requireRoles(roleIDs []snowflake.ID, all bool, opts []GuardConfigOpt) handler.Middleware {
+	return Guard(func(event *handler.InteractionEvent) (*GuardDenial, error) {
+		member := event.Member()
+		if member == nil {
+			return &GuardDenial{Reason: GuardReasonGuildOnly}, nil
+		}
+
+		allowed := all
+		for _, roleID := range roleIDs {
+			if slices.Contains(member.RoleIDs, roleID) != all {
+				allowed = !all
+				break
+			}
+		}
+		if !allowed {
+			return &GuardDenial{Reason: GuardReasonRoles, RoleIDs: roleIDs, AllRoles: all}, nil
+		}
+		return nil, nil
+	}, opts...)
+}
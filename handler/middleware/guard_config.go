@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/disgoorg/disgo/handler"
+)
+
+// DefaultGuardConfig returns the default GuardConfig.
+func DefaultGuardConfig() *GuardConfig {
+	return &GuardConfig{
+		Message: DefaultGuardMessage,
+	}
+}
+
+// GuardConfig is the configuration of the guard middlewares like RequirePermissions or GuildOnly.
+type GuardConfig struct {
+	// Message returns the content of the ephemeral reply to denied interactions.
+	Message func(event *handler.InteractionEvent, denial GuardDenial) string
+	// OnDenied replaces the reply to denied interactions.
+	OnDenied func(event *handler.InteractionEvent, denial GuardDenial) error
+}
+
+// GuardConfigOpt is a type alias for a function that takes a GuardConfig and is used to configure your guard middlewares.
+type GuardConfigOpt func(config *GuardConfig)
+
+// Apply applies the given GuardConfigOpt(s) to the GuardConfig.
+func (c *GuardConfig) Apply(opts []GuardConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithGuardMessage sets the function which returns the content of the ephemeral reply to denied interactions.
+func WithGuardMessage(message func(event *handler.InteractionEvent, denial GuardDenial) string) GuardConfigOpt {
+	return func(config *GuardConfig) {
+		config.Message = message
+	}
+}
+
+// WithGuardDenied sets a function which replaces the reply to denied interactions.
+func WithGuardDenied(onDenied func(event *handler.InteractionEvent, denial GuardDenial) error) GuardConfigOpt {
+	return func(config *GuardConfig) {
+		config.OnDenied = onDenied
+	}
+}
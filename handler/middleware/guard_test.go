@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+func TestGuards(t *testing.T) {
+	const (
+		roleA = snowflake.ID(100)
+		roleB = snowflake.ID(200)
+	)
+	ownerID := snowflake.ID(1300000000000000001)
+
+	tests := []struct {
+		name       string
+		middleware handler.Middleware
+		opts       []handlertest.InteractionOpt
+		denied     string
+	}{
+		{
+			name:       "member permissions",
+			middleware: RequirePermissions(discord.PermissionManageMessages | discord.PermissionBanMembers),
+			opts:       []handlertest.InteractionOpt{handlertest.WithPermissions(discord.PermissionBanMembers)},
+			denied:     "You are missing the following permissions: Manage Messages.",
+		},
+		{
+			name:       "member permissions allowed",
+			middleware: RequirePermissions(discord.PermissionBanMembers),
+			opts:       []handlertest.InteractionOpt{handlertest.WithPermissions(discord.PermissionBanMembers | discord.PermissionKickMembers)},
+		},
+		{
+			name:       "member permissions in dm",
+			middleware: RequirePermissions(discord.PermissionBanMembers),
+			opts:       []handlertest.InteractionOpt{handlertest.WithDM()},
+			denied:     "This can only be used in servers.",
+		},
+		{
+			name:       "bot permissions",
+			middleware: RequireBotPermissions(discord.PermissionSendMessages | discord.PermissionEmbedLinks),
+			opts:       []handlertest.InteractionOpt{handlertest.WithAppPermissions(discord.PermissionSendMessages)},
+			denied:     "I am missing the following permissions: Embed Links.",
+		},
+		{
+			name:       "guild only",
+			middleware: GuildOnly(),
+			opts:       []handlertest.InteractionOpt{handlertest.WithDM()},
+			denied:     "This can only be used in servers.",
+		},
+		{
+			name:       "guild only allowed",
+			middleware: GuildOnly(),
+		},
+		{
+			name:       "dm only",
+			middleware: DMOnly(),
+			denied:     "This can only be used in direct messages.",
+		},
+		{
+			name:       "owner only",
+			middleware: OwnerOnly([]snowflake.ID{ownerID}),
+			opts:       []handlertest.InteractionOpt{handlertest.WithUser(discord.User{ID: 42})},
+			denied:     "This can only be used by the owners of the bot.",
+		},
+		{
+			name:       "owner only allowed",
+			middleware: OwnerOnly([]snowflake.ID{ownerID}),
+		},
+		{
+			name:       "any role",
+			middleware: RequireAnyRole([]snowflake.ID{roleA, roleB}),
+			opts:       []handlertest.InteractionOpt{handlertest.WithRoles(roleB)},
+		},
+		{
+			name:       "all roles",
+			middleware: RequireAllRoles([]snowflake.ID{roleA, roleB}),
+			opts:       []handlertest.InteractionOpt{handlertest.WithRoles(roleB)},
+			denied:     "You need all of the following roles: <@&100>, <@&200>.",
+		},
+		{
+			name:       "any role denied",
+			middleware: RequireAnyRole([]snowflake.ID{roleA}),
+			opts:       []handlertest.InteractionOpt{handlertest.WithRoles(roleB)},
+			denied:     "You need one of the following roles: <@&100>.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			mux := handler.New()
+			mux.Use(tt.middleware)
+			mux.SlashCommand("/test", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+				called = true
+				return e.CreateMessage(discord.MessageCreate{Content: "ok"})
+			})
+
+			rec := handlertest.NewRecorder()
+			rec.Serve(mux, handlertest.NewSlashCommandInteraction("/test", tt.opts...))
+			response, ok := rec.Response()
+			require.True(t, ok)
+			message, ok := response.Data.(discord.MessageCreate)
+			require.True(t, ok)
+
+			if tt.denied == "" {
+				assert.True(t, called)
+				assert.Equal(t, "ok", message.Content)
+				return
+			}
+			assert.False(t, called)
+			assert.Equal(t, tt.denied, message.Content)
+			assert.Equal(t, discord.MessageFlagEphemeral, message.Flags)
+		})
+	}
+}
+
+func TestGuardCustomMessage(t *testing.T) {
+	mux := handler.New()
+	mux.Use(GuildOnly(WithGuardMessage(func(e *handler.InteractionEvent, denial GuardDenial) string {
+		assert.Equal(t, GuardReasonGuildOnly, denial.Reason)
+		return "servers only"
+	})))
+	mux.SlashCommand("/test", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: "ok"})
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/test", handlertest.WithDM()))
+	response, ok := rec.Response()
+	require.True(t, ok)
+	assert.Equal(t, "servers only", response.Data.(discord.MessageCreate).Content)
+}
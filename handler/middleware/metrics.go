@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/metrics"
+)
+
+// Metrics is a middleware that reports the latency and errors of the next handler to the given metrics.Recorder.
+// The route is the matched route pattern of the interaction (see handler.InteractionEvent.Route), which keeps the number of routes low.
+func Metrics(recorder metrics.Recorder) handler.Middleware {
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			start := time.Now()
+			err := next(event)
+			recorder.HandlerCompleted(interactionTypeName(event.Type()), event.Route(), time.Since(start), err)
+			return err
+		}
+	}
+}
+
+func interactionTypeName(t discord.InteractionType) string {
+	switch t {
+	case discord.InteractionTypePing:
+		return "ping"
+	case discord.InteractionTypeApplicationCommand:
+		return "application_command"
+	case discord.InteractionTypeComponent:
+		return "component"
+	case discord.InteractionTypeAutocomplete:
+		return "autocomplete"
+	case discord.InteractionTypeModalSubmit:
+		return "modal_submit"
+	}
+	return "unknown"
+}
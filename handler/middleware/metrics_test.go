@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/handler/handlertest"
+	"github.com/disgoorg/disgo/metrics"
+)
+
+type routeRecorder struct {
+	metrics.Recorder
+	routes []string
+}
+
+func (r *routeRecorder) HandlerCompleted(_ string, route string, _ time.Duration, _ error) {
+	r.routes = append(r.routes, route)
+}
+
+func TestMetricsRoute(t *testing.T) {
+	recorder := &routeRecorder{Recorder: metrics.Noop}
+	mux := handler.New()
+	mux.Use(Metrics(recorder))
+	mux.Route("/color", func(r handler.Router) {
+		r.ButtonComponent("/{hex}", func(data discord.ButtonInteractionData, e *handler.ComponentEvent) error {
+			return e.DeferUpdateMessage()
+		})
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewButtonInteraction("/color/#ff0000"))
+	rec.Serve(mux, handlertest.NewButtonInteraction("/color/ff0000"))
+
+	assert.Equal(t, []string{"/color/{hex}", "/color/{hex}"}, recorder.routes)
+}
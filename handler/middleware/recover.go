@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/disgoorg/disgo/handler"
+)
+
+// PanicError is returned by Recover when the next handler panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Recover is a middleware which recovers panics of the next handler and returns them as *PanicError.
+// Without Recover, panics are only recovered by the bot.EventManager and the user never receives a response.
+// Use it together with Errors to reply to the user, for example with mux.Use(middleware.Errors(), middleware.Recover).
+var Recover handler.Middleware = func(next handler.Handler) handler.Handler {
+	return func(event *handler.InteractionEvent) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+		return next(event)
+	}
+}
@@ -2,12 +2,18 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 var defaultErrorHandler ErrorHandler = func(event *InteractionEvent, err error) {
@@ -32,9 +38,19 @@ type Mux struct {
 	pattern         string
 	middlewares     []Middleware
 	routes          []Route
+	commands        []CommandDefinition
 	notFoundHandler NotFoundHandler
 	errorHandler    ErrorHandler
 	defaultContext  func() context.Context
+	tracer          tracing.Tracer
+	state           *stateConfig
+}
+
+var _ bot.TypedEventListener = (*Mux)(nil)
+
+// EventType returns the type of *events.InteractionCreate, so the bot.EventManager can skip building other events for the Mux.
+func (r *Mux) EventType() reflect.Type {
+	return reflect.TypeOf((*events.InteractionCreate)(nil))
 }
 
 // OnEvent is called when a new event is received.
@@ -67,12 +83,43 @@ func (r *Mux) OnEvent(event bot.Event) {
 		ctx = context.Background()
 	}
 
+	var state *State
+	if r.state != nil {
+		ctx = contextWithStateConfig(ctx, r.state)
+		if t := e.Type(); t == discord.InteractionTypeComponent || t == discord.InteractionTypeModalSubmit {
+			var stateID string
+			if path, stateID = SplitCustomID(path); stateID != "" {
+				state = restoreState(ctx, r.state, stateID)
+			}
+		}
+	}
+
+	var span tracing.Span
+	if r.tracer != nil {
+		attrs := []tracing.Attribute{
+			tracing.Int64("interaction.id", int64(e.ID())),
+			tracing.Int("interaction.type", int(e.Type())),
+			tracing.String("interaction.path", path),
+		}
+		if guildID := e.GuildID(); guildID != nil {
+			attrs = append(attrs, tracing.Int64("guild.id", int64(*guildID)))
+		}
+		ctx, span = r.tracer.Start(tracing.ContextWithTracer(ctx, r.tracer), "handler.interaction", attrs...)
+		defer span.End()
+	}
+
 	ie := &InteractionEvent{
 		InteractionCreate: e,
 		Ctx:               ctx,
 		Vars:              make(map[string]string),
+		State:             state,
+		route:             new(string),
+		ack:               &acknowledgement{respond: e.Respond},
 	}
 	if err := r.Handle(path, ie); err != nil {
+		if span != nil {
+			span.RecordError(err)
+		}
 		if r.errorHandler != nil {
 			r.errorHandler(ie, err)
 			return
@@ -108,9 +155,10 @@ func (r *Mux) Match(path string, t discord.InteractionType, t2 int) bool {
 
 // Handle handles the given interaction event.
 func (r *Mux) Handle(path string, event *InteractionEvent) error {
+	// parse the variables of this router before its middlewares, so they can use them
+	path = parseVariables(path, r.pattern, event.Vars)
+	event.appendRoute(r.pattern)
 	handlerChain := Handler(func(event *InteractionEvent) error {
-		path = parseVariables(path, r.pattern, event.Vars)
-
 		t := event.Type()
 		var t2 int
 		switch i := event.Interaction.(type) {
@@ -131,8 +179,15 @@ func (r *Mux) Handle(path string, event *InteractionEvent) error {
 		return nil
 	})
 
+	traced := tracingEnabled(event.Ctx)
 	for i := len(r.middlewares) - 1; i >= 0; i-- {
 		handlerChain = r.middlewares[i](handlerChain)
+		if traced {
+			handlerChain = traceHandler("handler.middleware", handlerChain, tracing.Int("handler.middleware.index", i), tracing.String("handler.middleware.name", middlewareName(r.middlewares[i])))
+		}
+	}
+	if traced {
+		handlerChain = traceHandler("handler.mux", handlerChain, tracing.String("handler.pattern", r.pattern))
 	}
 
 	return handlerChain(event)
@@ -177,6 +232,51 @@ func (r *Mux) handle(route Route) {
 	r.routes = append(r.routes, route)
 }
 
+// Commands registers the handlers of the given CommandDefinition(s) to the current Router.
+// Commands can only be registered to routers without a pattern, as the routes are generated from the command names.
+func (r *Mux) Commands(commands ...CommandDefinition) {
+	if r.pattern != "" {
+		panic("commands must not be registered to a router with a pattern")
+	}
+	for _, command := range commands {
+		command.register(r)
+	}
+	r.commands = append(r.commands, commands...)
+}
+
+// CommandDefinitions returns all CommandDefinition(s) registered to the current Router and its sub-routers.
+func (r *Mux) CommandDefinitions() []CommandDefinition {
+	commands := slices.Clone(r.commands)
+	for _, route := range r.routes {
+		if router, ok := route.(*Mux); ok {
+			commands = append(commands, router.CommandDefinitions()...)
+		}
+	}
+	return commands
+}
+
+// CheckCommands returns an error if a command or autocomplete route of the current Router and its sub-routers does not match any registered CommandDefinition
+// or if multiple CommandDefinition(s) of the same type have the same name.
+// Call it at startup to catch routes and commands which drifted apart.
+func (r *Mux) CheckCommands() error {
+	commands := r.CommandDefinitions()
+
+	var errs []error
+	names := make(map[string]struct{}, len(commands))
+	for _, command := range commands {
+		key := fmt.Sprintf("%d:%s", command.ApplicationCommandCreate().Type(), command.CommandName())
+		if _, ok := names[key]; ok {
+			errs = append(errs, fmt.Errorf("command %s is registered multiple times", command.CommandName()))
+			continue
+		}
+		names[key] = struct{}{}
+	}
+	if err := checkCommandRoutes(commandRoutes("", r), commands); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
 // Interaction registers the given InteractionHandler to the current Router.
 // This is a shortcut for Command, Autocomplete, Component and Modal.
 func (r *Mux) Interaction(pattern string, h InteractionHandler) {
@@ -312,6 +412,23 @@ func (r *Mux) Error(h ErrorHandler) {
 	r.errorHandler = h
 }
 
+// Tracer sets the tracing.Tracer for this router.
+// It traces every interaction from the InteractionEvent through all sub-routers, middlewares, handlers and rest.Client requests made with the InteractionEvent.Ctx.
+// This tracer only works for the root router and will be ignored for sub routers.
+func (r *Mux) Tracer(tracer tracing.Tracer) {
+	r.tracer = tracer
+}
+
+// State sets the StateStore and ttl for the states of components and modals created with NewState.
+// The State is restored from custom ids created with State.CustomID into ComponentEvent.State and ModalEvent.State, and the handlers are routed by the custom id without the state id.
+// This store only works for the root router and will be ignored for sub routers.
+func (r *Mux) State(store StateStore, ttl time.Duration) {
+	r.state = &stateConfig{
+		store: store,
+		ttl:   ttl,
+	}
+}
+
 // DefaultContext sets the default context for this router.
 // This context will be used for all interaction events.
 func (r *Mux) DefaultContext(ctx func() context.Context) {
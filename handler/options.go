@@ -0,0 +1,400 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Mentionable is a resolved user, member or role of a mentionable option. Use it as field type to bind mentionable options.
+type Mentionable struct {
+	ID     snowflake.ID
+	User   *discord.User
+	Member *discord.ResolvedMember
+	Role   *discord.Role
+}
+
+// OptionError is a validation error of a single option.
+type OptionError struct {
+	Option  string
+	Message string
+}
+
+func (e OptionError) Error() string {
+	return "option " + e.Option + " " + e.Message
+}
+
+// OptionErrors is returned by BindOptions if options don't pass the validation.
+type OptionErrors []OptionError
+
+func (e OptionErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, ", ")
+}
+
+// BindSlashCommand returns a SlashCommandHandler which binds the options into a new T with BindOptions before calling the handler.
+// If the options can't be bound, the error is returned without calling the handler.
+func BindSlashCommand[T any](h func(options T, e *CommandEvent) error) SlashCommandHandler {
+	return func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		var options T
+		if err := BindOptions(data, &options); err != nil {
+			return err
+		}
+		return h(options, e)
+	}
+}
+
+// BindOptions binds the options of the slash command into v, which must be a pointer to a struct.
+// The options are bound into the struct fields by the discord tag:
+//
+//	type BanOptions struct {
+//		User   discord.ResolvedMember `discord:"user,required" description:"The member to ban"`
+//		Reason *string                `discord:"reason,max=512" description:"Why the member is banned"`
+//		Days   int                    `discord:"days,min=0,max=7" description:"How many days of messages to delete"`
+//	}
+//
+// The tag starts with the option name, followed by these optional flags:
+//   - required: the option must be set
+//   - min=N, max=N: the minimum and maximum value of numbers or length of strings
+//   - autocomplete: the option uses autocomplete
+//
+// Supported field types are string, signed integers, floats, bool, discord.User, discord.ResolvedMember, discord.Role, discord.ResolvedChannel, discord.Attachment and Mentionable.
+// Pointer fields are set to nil if the option is missing, other fields keep their zero value.
+// Validation errors are returned as OptionErrors.
+func BindOptions(data discord.SlashCommandInteractionData, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("options must be bound into a non-nil pointer to a struct")
+	}
+	fields, err := optionFieldsOf(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	var errs OptionErrors
+	for _, field := range fields {
+		if err = field.bind(data, rv.Elem().FieldByIndex(field.index)); err != nil {
+			errs = append(errs, OptionError{Option: field.name, Message: err.Error()})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// CommandOptions returns the discord.ApplicationCommandOption(s) for the fields of the struct T as described in BindOptions.
+// The description of an option is read from the description tag. It panics if T is not a struct or has invalid tags.
+func CommandOptions[T any]() []discord.ApplicationCommandOption {
+	var zero T
+	fields, err := optionFieldsOf(reflect.TypeOf(zero))
+	if err != nil {
+		panic(err)
+	}
+	options := make([]discord.ApplicationCommandOption, 0, len(fields))
+	for _, field := range fields {
+		options = append(options, field.option())
+	}
+	return options
+}
+
+var (
+	userType            = reflect.TypeOf(discord.User{})
+	resolvedMemberType  = reflect.TypeOf(discord.ResolvedMember{})
+	roleType            = reflect.TypeOf(discord.Role{})
+	resolvedChannelType = reflect.TypeOf(discord.ResolvedChannel{})
+	attachmentType      = reflect.TypeOf(discord.Attachment{})
+	mentionableType     = reflect.TypeOf(Mentionable{})
+)
+
+var optionFieldsCache sync.Map // map[reflect.Type]optionFieldsResult
+
+type optionFieldsResult struct {
+	fields []optionField
+	err    error
+}
+
+func optionFieldsOf(t reflect.Type) ([]optionField, error) {
+	if result, ok := optionFieldsCache.Load(t); ok {
+		return result.(optionFieldsResult).fields, result.(optionFieldsResult).err
+	}
+	fields, err := parseOptionFields(t)
+	optionFieldsCache.Store(t, optionFieldsResult{fields: fields, err: err})
+	return fields, err
+}
+
+type optionField struct {
+	index        []int
+	name         string
+	description  string
+	optionType   discord.ApplicationCommandOptionType
+	valueType    reflect.Type
+	pointer      bool
+	required     bool
+	autocomplete bool
+	min          *float64
+	max          *float64
+}
+
+func parseOptionFields(t reflect.Type) ([]optionField, error) {
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be a struct, got %v", t)
+	}
+
+	var fields []optionField
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, ok := structField.Tag.Lookup("discord")
+		if !ok || tag == "-" || !structField.IsExported() {
+			continue
+		}
+		field, err := parseOptionField(structField, tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid option field %s.%s: %w", t.Name(), structField.Name, err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func parseOptionField(structField reflect.StructField, tag string) (optionField, error) {
+	parts := strings.Split(tag, ",")
+	field := optionField{
+		index:       structField.Index,
+		name:        parts[0],
+		description: structField.Tag.Get("description"),
+		valueType:   structField.Type,
+	}
+	if field.name == "" {
+		field.name = strings.ToLower(structField.Name)
+	}
+	if field.description == "" {
+		field.description = field.name
+	}
+	if field.valueType.Kind() == reflect.Pointer {
+		field.pointer = true
+		field.valueType = field.valueType.Elem()
+	}
+
+	switch field.valueType {
+	case userType, resolvedMemberType:
+		field.optionType = discord.ApplicationCommandOptionTypeUser
+	case roleType:
+		field.optionType = discord.ApplicationCommandOptionTypeRole
+	case resolvedChannelType:
+		field.optionType = discord.ApplicationCommandOptionTypeChannel
+	case attachmentType:
+		field.optionType = discord.ApplicationCommandOptionTypeAttachment
+	case mentionableType:
+		field.optionType = discord.ApplicationCommandOptionTypeMentionable
+	default:
+		switch field.valueType.Kind() {
+		case reflect.String:
+			field.optionType = discord.ApplicationCommandOptionTypeString
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.optionType = discord.ApplicationCommandOptionTypeInt
+		case reflect.Float32, reflect.Float64:
+			field.optionType = discord.ApplicationCommandOptionTypeFloat
+		case reflect.Bool:
+			field.optionType = discord.ApplicationCommandOptionTypeBool
+		default:
+			return field, fmt.Errorf("unsupported type %s", structField.Type)
+		}
+	}
+
+	for _, flag := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(flag), "=")
+		switch key {
+		case "required":
+			field.required = true
+		case "autocomplete":
+			if !field.isNumberOrString() {
+				return field, errors.New("autocomplete is only supported for strings and numbers")
+			}
+			field.autocomplete = true
+		case "min", "max":
+			if !field.isNumberOrString() {
+				return field, fmt.Errorf("%s is only supported for strings and numbers", key)
+			}
+			limit, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return field, fmt.Errorf("invalid %s value %q: %w", key, value, err)
+			}
+			if key == "min" {
+				field.min = &limit
+			} else {
+				field.max = &limit
+			}
+		default:
+			return field, fmt.Errorf("unknown flag %q", key)
+		}
+	}
+	return field, nil
+}
+
+func (f optionField) isNumberOrString() bool {
+	return f.optionType == discord.ApplicationCommandOptionTypeString || f.optionType == discord.ApplicationCommandOptionTypeInt || f.optionType == discord.ApplicationCommandOptionTypeFloat
+}
+
+func (f optionField) option() discord.ApplicationCommandOption {
+	switch f.optionType {
+	case discord.ApplicationCommandOptionTypeString:
+		return discord.ApplicationCommandOptionString{Name: f.name, Description: f.description, Required: f.required, Autocomplete: f.autocomplete, MinLength: intLimit(f.min), MaxLength: intLimit(f.max)}
+	case discord.ApplicationCommandOptionTypeInt:
+		return discord.ApplicationCommandOptionInt{Name: f.name, Description: f.description, Required: f.required, Autocomplete: f.autocomplete, MinValue: intLimit(f.min), MaxValue: intLimit(f.max)}
+	case discord.ApplicationCommandOptionTypeFloat:
+		return discord.ApplicationCommandOptionFloat{Name: f.name, Description: f.description, Required: f.required, Autocomplete: f.autocomplete, MinValue: f.min, MaxValue: f.max}
+	case discord.ApplicationCommandOptionTypeBool:
+		return discord.ApplicationCommandOptionBool{Name: f.name, Description: f.description, Required: f.required}
+	case discord.ApplicationCommandOptionTypeUser:
+		return discord.ApplicationCommandOptionUser{Name: f.name, Description: f.description, Required: f.required}
+	case discord.ApplicationCommandOptionTypeRole:
+		return discord.ApplicationCommandOptionRole{Name: f.name, Description: f.description, Required: f.required}
+	case discord.ApplicationCommandOptionTypeChannel:
+		return discord.ApplicationCommandOptionChannel{Name: f.name, Description: f.description, Required: f.required}
+	case discord.ApplicationCommandOptionTypeAttachment:
+		return discord.ApplicationCommandOptionAttachment{Name: f.name, Description: f.description, Required: f.required}
+	default:
+		return discord.ApplicationCommandOptionMentionable{Name: f.name, Description: f.description, Required: f.required}
+	}
+}
+
+func intLimit(limit *float64) *int {
+	if limit == nil {
+		return nil
+	}
+	i := int(*limit)
+	return &i
+}
+
+func (f optionField) bind(data discord.SlashCommandInteractionData, field reflect.Value) error {
+	if _, ok := data.Option(f.name); !ok {
+		if f.required {
+			return errors.New("is required")
+		}
+		return nil
+	}
+
+	value, err := f.value(data)
+	if err != nil {
+		return err
+	}
+	if err = f.validate(value); err != nil {
+		return err
+	}
+
+	if f.pointer {
+		ptr := reflect.New(f.valueType)
+		ptr.Elem().Set(value)
+		field.Set(ptr)
+		return nil
+	}
+	field.Set(value)
+	return nil
+}
+
+func (f optionField) value(data discord.SlashCommandInteractionData) (reflect.Value, error) {
+	var (
+		value any
+		ok    bool
+	)
+	switch f.valueType {
+	case userType:
+		value, ok = data.OptUser(f.name)
+	case resolvedMemberType:
+		value, ok = data.OptMember(f.name)
+	case roleType:
+		value, ok = data.OptRole(f.name)
+	case resolvedChannelType:
+		value, ok = data.OptChannel(f.name)
+	case attachmentType:
+		value, ok = data.OptAttachment(f.name)
+	case mentionableType:
+		value, ok = resolveMentionable(data, f.name)
+	default:
+		switch f.optionType {
+		case discord.ApplicationCommandOptionTypeString:
+			value, ok = data.OptString(f.name)
+		case discord.ApplicationCommandOptionTypeInt:
+			var i int
+			if i, ok = data.OptInt(f.name); ok {
+				rv := reflect.New(f.valueType).Elem()
+				if rv.OverflowInt(int64(i)) {
+					return reflect.Value{}, errors.New("is out of range")
+				}
+				rv.SetInt(int64(i))
+				return rv, nil
+			}
+		case discord.ApplicationCommandOptionTypeFloat:
+			var fl float64
+			if fl, ok = data.OptFloat(f.name); ok {
+				rv := reflect.New(f.valueType).Elem()
+				rv.SetFloat(fl)
+				return rv, nil
+			}
+		case discord.ApplicationCommandOptionTypeBool:
+			value, ok = data.OptBool(f.name)
+		}
+	}
+	if !ok {
+		if f.optionType == discord.ApplicationCommandOptionTypeString || f.optionType == discord.ApplicationCommandOptionTypeInt || f.optionType == discord.ApplicationCommandOptionTypeFloat || f.optionType == discord.ApplicationCommandOptionTypeBool {
+			return reflect.Value{}, errors.New("has an invalid value")
+		}
+		return reflect.Value{}, errors.New("could not be resolved")
+	}
+	return reflect.ValueOf(value).Convert(f.valueType), nil
+}
+
+func (f optionField) validate(value reflect.Value) error {
+	if f.min == nil && f.max == nil {
+		return nil
+	}
+	var (
+		n    float64
+		unit string
+	)
+	switch value.Kind() {
+	case reflect.String:
+		n = float64(len([]rune(value.String())))
+		unit = " characters"
+	case reflect.Float32, reflect.Float64:
+		n = value.Float()
+	default:
+		n = float64(value.Int())
+	}
+	if f.min != nil && n < *f.min {
+		return fmt.Errorf("must be at least %s%s", strconv.FormatFloat(*f.min, 'f', -1, 64), unit)
+	}
+	if f.max != nil && n > *f.max {
+		return fmt.Errorf("must be at most %s%s", strconv.FormatFloat(*f.max, 'f', -1, 64), unit)
+	}
+	return nil
+}
+
+func resolveMentionable(data discord.SlashCommandInteractionData, name string) (Mentionable, bool) {
+	id, ok := data.OptSnowflake(name)
+	if !ok {
+		return Mentionable{}, false
+	}
+	mentionable := Mentionable{ID: id}
+	if user, ok := data.Resolved.Users[id]; ok {
+		mentionable.User = &user
+	}
+	if member, ok := data.Resolved.Members[id]; ok {
+		mentionable.Member = &member
+	}
+	if role, ok := data.Resolved.Roles[id]; ok {
+		mentionable.Role = &role
+	}
+	return mentionable, mentionable.User != nil || mentionable.Role != nil
+}
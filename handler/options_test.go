@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+type banOptions struct {
+	Member  discord.ResolvedMember `discord:"member,required" description:"The member to ban"`
+	Reason  *string                `discord:"reason,min=3,max=10" description:"Why the member is banned"`
+	Days    int8                   `discord:"days,min=0,max=7"`
+	Notify  bool                   `discord:"notify"`
+	Target  *Mentionable           `discord:"target"`
+	Channel *discord.ResolvedChannel
+}
+
+func TestCommandOptions(t *testing.T) {
+	minLength, maxLength := 3, 10
+	minDays, maxDays := 0, 7
+	assert.Equal(t, []discord.ApplicationCommandOption{
+		discord.ApplicationCommandOptionUser{Name: "member", Description: "The member to ban", Required: true},
+		discord.ApplicationCommandOptionString{Name: "reason", Description: "Why the member is banned", MinLength: &minLength, MaxLength: &maxLength},
+		discord.ApplicationCommandOptionInt{Name: "days", Description: "days", MinValue: &minDays, MaxValue: &maxDays},
+		discord.ApplicationCommandOptionBool{Name: "notify", Description: "notify"},
+		discord.ApplicationCommandOptionMentionable{Name: "target", Description: "target"},
+	}, CommandOptions[banOptions]())
+
+	assert.Panics(t, func() {
+		CommandOptions[struct {
+			User discord.User `discord:"user,max=3"`
+		}]()
+	})
+}
+
+func TestBindOptions(t *testing.T) {
+	member := discord.Member{User: discord.User{ID: 1300000000000000002, Username: "member"}}
+	role := discord.Role{ID: 1400000000000000001, Name: "role"}
+
+	interaction := handlertest.NewSlashCommandInteraction("/ban",
+		handlertest.WithOption("member", member),
+		handlertest.WithOption("reason", "spam"),
+		handlertest.WithOption("days", 7),
+		handlertest.WithOption("target", role),
+	)
+	var options banOptions
+	require.NoError(t, BindOptions(interaction.SlashCommandInteractionData(), &options))
+	assert.Equal(t, member.User, options.Member.User)
+	require.NotNil(t, options.Reason)
+	assert.Equal(t, "spam", *options.Reason)
+	assert.Equal(t, int8(7), options.Days)
+	assert.False(t, options.Notify)
+	require.NotNil(t, options.Target)
+	assert.Equal(t, &role, options.Target.Role)
+	assert.Nil(t, options.Target.User)
+
+	interaction = handlertest.NewSlashCommandInteraction("/ban",
+		handlertest.WithOption("reason", "a very long reason"),
+		handlertest.WithOption("days", 300),
+	)
+	err := BindOptions(interaction.SlashCommandInteractionData(), &banOptions{})
+	var optionErrors OptionErrors
+	require.ErrorAs(t, err, &optionErrors)
+	assert.Equal(t, OptionErrors{
+		{Option: "member", Message: "is required"},
+		{Option: "reason", Message: "must be at most 10 characters"},
+		{Option: "days", Message: "is out of range"},
+	}, optionErrors)
+
+	assert.Error(t, BindOptions(interaction.SlashCommandInteractionData(), banOptions{}))
+}
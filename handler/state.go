@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+var (
+	// ErrStateNotFound is returned when the state of a component or modal does not exist or has expired.
+	ErrStateNotFound = errors.New("state not found")
+
+	// ErrNoStateStore is returned when creating a state without a StateStore configured with Mux.State.
+	ErrNoStateStore = errors.New("no state store configured")
+)
+
+// stateSeparator separates the path of a custom id from the state id.
+const stateSeparator = "#"
+
+// maxCustomIDLength is the maximum length of a custom id allowed by Discord.
+const maxCustomIDLength = 100
+
+// StateStore stores the state of components and modals under a short id.
+// All methods are called from multiple goroutines.
+type StateStore interface {
+	// Get returns the data stored under the id or ErrStateNotFound if it does not exist or has expired.
+	Get(ctx context.Context, id string) ([]byte, error)
+
+	// Set stores the data under the id for the given ttl.
+	Set(ctx context.Context, id string, data []byte, ttl time.Duration) error
+
+	// Delete deletes the data stored under the id. Deleting data which doesn't exist is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+var _ StateStore = (*memoryStateStore)(nil)
+
+// NewMemoryStateStore returns a StateStore which keeps all states in memory. States are lost on restart.
+func NewMemoryStateStore() StateStore {
+	return &memoryStateStore{
+		states: map[string]memoryState{},
+	}
+}
+
+type memoryState struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+type memoryStateStore struct {
+	mu        sync.Mutex
+	states    map[string]memoryState
+	lastSweep time.Time
+}
+
+func (s *memoryStateStore) Get(_ context.Context, id string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[id]
+	if !ok || time.Now().After(state.expiresAt) {
+		return nil, ErrStateNotFound
+	}
+	return state.data, nil
+}
+
+func (s *memoryStateStore) Set(_ context.Context, id string, data []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.states[id] = memoryState{
+		data:      data,
+		expiresAt: now.Add(ttl),
+	}
+
+	// remove expired states at most once a minute
+	if now.Sub(s.lastSweep) > time.Minute {
+		s.lastSweep = now
+		for stateID, state := range s.states {
+			if now.After(state.expiresAt) {
+				delete(s.states, stateID)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *memoryStateStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, id)
+	return nil
+}
+
+// SplitCustomID splits a custom id into its path and the id of its state. The state id is empty for custom ids without state.
+// Only a suffix which looks like a state id created by NewState is split off, so custom ids like /color/#ff0000 keep their path.
+func SplitCustomID(customID string) (string, string) {
+	i := strings.LastIndex(customID, stateSeparator)
+	if i < 0 || !isStateID(customID[i+len(stateSeparator):]) {
+		return customID, ""
+	}
+	return customID[:i], customID[i+len(stateSeparator):]
+}
+
+type stateConfig struct {
+	store StateStore
+	ttl   time.Duration
+}
+
+type stateConfigKey struct{}
+
+func contextWithStateConfig(ctx context.Context, cfg *stateConfig) context.Context {
+	return context.WithValue(ctx, stateConfigKey{}, cfg)
+}
+
+func stateConfigFromContext(ctx context.Context) *stateConfig {
+	if ctx == nil {
+		return nil
+	}
+	cfg, _ := ctx.Value(stateConfigKey{}).(*stateConfig)
+	return cfg
+}
+
+// State is the server-side state of a component or modal. It is stored in the StateStore configured with Mux.State under a short id which is part of the custom id.
+// The Mux restores the State of component and modal interactions into ComponentEvent.State and ModalEvent.State.
+type State struct {
+	ID string
+
+	cfg  *stateConfig
+	data []byte
+	err  error
+}
+
+// NewState stores v encoded as JSON in the StateStore of the Mux which handles the interaction of ctx.
+// Use State.CustomID to create the custom id of a component or modal which restores the state.
+func NewState(ctx context.Context, v any) (*State, error) {
+	cfg := stateConfigFromContext(ctx)
+	if cfg == nil {
+		return nil, ErrNoStateStore
+	}
+	id, err := newStateID()
+	if err != nil {
+		return nil, err
+	}
+	state := &State{
+		ID:  id,
+		cfg: cfg,
+	}
+	if err = state.Update(ctx, v); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// StatefulCustomID stores v with NewState and returns the custom id for the path which restores it.
+func StatefulCustomID(ctx context.Context, path string, v any) (string, error) {
+	state, err := NewState(ctx, v)
+	if err != nil {
+		return "", err
+	}
+	return state.CustomID(path)
+}
+
+// restoreState loads the State with the id from the StateStore. Errors are returned by State.Unmarshal.
+func restoreState(ctx context.Context, cfg *stateConfig, id string) *State {
+	state := &State{
+		ID:  id,
+		cfg: cfg,
+	}
+	state.data, state.err = cfg.store.Get(ctx, id)
+	return state
+}
+
+// CustomID returns the custom id for the path which restores the State.
+// It returns an error if the custom id is longer than the 100 characters allowed by Discord.
+func (s *State) CustomID(path string) (string, error) {
+	customID := path + stateSeparator + s.ID
+	if len(customID) > maxCustomIDLength {
+		return "", fmt.Errorf("custom id %s is longer than %d characters", customID, maxCustomIDLength)
+	}
+	return customID, nil
+}
+
+// Unmarshal decodes the State into v. It returns ErrStateNotFound if the State does not exist or has expired.
+func (s *State) Unmarshal(v any) error {
+	if s.err != nil {
+		return s.err
+	}
+	return json.Unmarshal(s.data, v)
+}
+
+// Update replaces the State with v and resets its ttl. The id and custom ids of the State stay the same.
+func (s *State) Update(ctx context.Context, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err = s.cfg.store.Set(ctx, s.ID, data, s.cfg.ttl); err != nil {
+		return fmt.Errorf("failed to store state: %w", err)
+	}
+	s.data, s.err = data, nil
+	return nil
+}
+
+// Delete deletes the State, for example when a multi-step flow is completed.
+func (s *State) Delete(ctx context.Context) error {
+	s.data, s.err = nil, ErrStateNotFound
+	return s.cfg.store.Delete(ctx, s.ID)
+}
+
+// stateIDLength is the length of the 8 random bytes of a state id encoded as raw base64url.
+const stateIDLength = 11
+
+func newStateID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate state id: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func isStateID(id string) bool {
+	if len(id) != stateIDLength {
+		return false
+	}
+	b, err := base64.RawURLEncoding.Strict().DecodeString(id)
+	return err == nil && len(b) == 8
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler/handlertest"
+)
+
+type pageState struct {
+	Query string `json:"query"`
+	Page  int    `json:"page"`
+}
+
+func TestState(t *testing.T) {
+	var customID string
+	mux := New()
+	mux.State(NewMemoryStateStore(), 50*time.Millisecond)
+	mux.SlashCommand("/search", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		var err error
+		customID, err = StatefulCustomID(e.Ctx, "/search/next", pageState{Query: data.String("query")})
+		return err
+	})
+	mux.ButtonComponent("/search/{action}", func(data discord.ButtonInteractionData, e *ComponentEvent) error {
+		require.NotNil(t, e.State)
+		var state pageState
+		if err := e.State.Unmarshal(&state); err != nil {
+			return e.CreateMessage(discord.MessageCreate{Content: err.Error()})
+		}
+		state.Page++
+		if err := e.State.Update(e.Ctx, state); err != nil {
+			return err
+		}
+		return e.CreateMessage(discord.MessageCreate{Content: e.Vars["action"] + " " + state.Query + " " + strconv.Itoa(state.Page)})
+	})
+
+	rec := handlertest.NewRecorder()
+	rec.Serve(mux, handlertest.NewSlashCommandInteraction("/search", handlertest.WithOption("query", "cats")))
+	path, stateID := SplitCustomID(customID)
+	assert.Equal(t, "/search/next", path)
+	assert.Len(t, stateID, 11)
+
+	for _, want := range []string{"next cats 1", "next cats 2"} {
+		rec.Reset()
+		rec.Serve(mux, handlertest.NewButtonInteraction(customID))
+		response, ok := rec.Response()
+		require.True(t, ok)
+		assert.Equal(t, discord.MessageCreate{Content: want}, response.Data)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	rec.Reset()
+	rec.Serve(mux, handlertest.NewButtonInteraction(customID))
+	response, _ := rec.Response()
+	assert.Equal(t, discord.MessageCreate{Content: ErrStateNotFound.Error()}, response.Data)
+
+	_, err := StatefulCustomID(context.Background(), "/search", nil)
+	assert.ErrorIs(t, err, ErrNoStateStore)
+}
+
+func TestSplitCustomID(t *testing.T) {
+	for customID, want := range map[string][2]string{
+		"/search/next#AbCdEf01_-w": {"/search/next", "AbCdEf01_-w"},
+		"/color/#ff0000":           {"/color/#ff0000", ""},
+		"/color/#ff0000ff001":      {"/color/#ff0000ff001", ""},
+		"/tag/#hello world":        {"/tag/#hello world", ""},
+		"/search":                  {"/search", ""},
+	} {
+		path, stateID := SplitCustomID(customID)
+		assert.Equal(t, want, [2]string{path, stateID}, customID)
+	}
+
+	var path string
+	mux := New()
+	mux.State(NewMemoryStateStore(), time.Minute)
+	mux.ButtonComponent("/color/{hex}", func(data discord.ButtonInteractionData, e *ComponentEvent) error {
+		assert.Nil(t, e.State)
+		path = e.Vars["hex"]
+		return nil
+	})
+	handlertest.NewRecorder().Serve(mux, handlertest.NewButtonInteraction("/color/#ff0000"))
+	assert.Equal(t, "#ff0000", path)
+}
@@ -0,0 +1,410 @@
+package handler
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// CommandChangeType is the type of CommandChange.
+type CommandChangeType int
+
+const (
+	// CommandChangeTypeCreate creates a new command.
+	CommandChangeTypeCreate CommandChangeType = iota
+	// CommandChangeTypeUpdate updates an existing command which differs from its discord.ApplicationCommandCreate.
+	CommandChangeTypeUpdate
+	// CommandChangeTypeDelete deletes an existing command which has no discord.ApplicationCommandCreate anymore.
+	CommandChangeTypeDelete
+)
+
+func (t CommandChangeType) String() string {
+	switch t {
+	case CommandChangeTypeCreate:
+		return "create"
+	case CommandChangeTypeUpdate:
+		return "update"
+	case CommandChangeTypeDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// CommandChange is a single change planned or applied by SyncCommandsIncremental.
+type CommandChange struct {
+	Type CommandChangeType
+	// GuildID is the guild of the command or nil for global commands.
+	GuildID     *snowflake.ID
+	Name        string
+	CommandType discord.ApplicationCommandType
+	// CommandID is the ID of the existing command for updates and deletes.
+	CommandID snowflake.ID
+	// Command is the new command for creates and updates.
+	Command discord.ApplicationCommandCreate
+}
+
+func (c CommandChange) String() string {
+	scope := "global"
+	if c.GuildID != nil {
+		scope = "guild " + c.GuildID.String()
+	}
+	return fmt.Sprintf("%s %s %s command %s", c.Type, scope, commandTypeName(c.CommandType), c.Name)
+}
+
+func commandTypeName(t discord.ApplicationCommandType) string {
+	switch t {
+	case discord.ApplicationCommandTypeSlash:
+		return "slash"
+	case discord.ApplicationCommandTypeUser:
+		return "user"
+	case discord.ApplicationCommandTypeMessage:
+		return "message"
+	case discord.ApplicationCommandTypePrimaryEntryPoint:
+		return "entry point"
+	}
+	return "unknown"
+}
+
+// CommandSyncReport lists the changes of SyncCommandsIncremental.
+type CommandSyncReport struct {
+	// Changes are the planned changes when running with WithDryRun or else the applied changes.
+	Changes []CommandChange
+	// Unchanged is the number of commands which are already up to date.
+	Unchanged int
+}
+
+// String returns the changes one per line.
+func (r CommandSyncReport) String() string {
+	var sb strings.Builder
+	for _, change := range r.Changes {
+		sb.WriteString(change.String())
+		sb.WriteByte('\n')
+	}
+	fmt.Fprintf(&sb, "%d changes, %d unchanged", len(r.Changes), r.Unchanged)
+	return sb.String()
+}
+
+// SyncCommandsIncremental syncs the given commands for the given guilds or globally if no guildIDs are given.
+// Unlike SyncCommands, it fetches the existing commands and only creates, updates or deletes the commands which changed, which keeps the IDs of unchanged commands and saves requests.
+// Commands are matched by their type and name and compared by their localizations, options, permissions, contexts and integration types.
+// A null default member permission allows everyone to use the command while 0 only allows administrators, so switching between them updates the command.
+//
+// It returns the applied changes or with WithDryRun only the planned changes. On error, the changes applied so far are returned.
+func SyncCommandsIncremental(client bot.Client, commands []discord.ApplicationCommandCreate, guildIDs []snowflake.ID, opts ...SyncConfigOpt) (*CommandSyncReport, error) {
+	cfg := DefaultSyncConfig()
+	cfg.Apply(opts)
+
+	report := &CommandSyncReport{}
+	if len(guildIDs) == 0 {
+		return report, syncCommands(client, nil, commands, cfg, report)
+	}
+	for _, guildID := range guildIDs {
+		guildID := guildID
+		if err := syncCommands(client, &guildID, commands, cfg, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func syncCommands(client bot.Client, guildID *snowflake.ID, commands []discord.ApplicationCommandCreate, cfg *SyncConfig, report *CommandSyncReport) error {
+	query := discord.QueryValues{"with_localizations": true}
+	endpoint := rest.GetGlobalCommands.Compile(query, client.ApplicationID())
+	if guildID != nil {
+		endpoint = rest.GetGuildCommands.Compile(query, client.ApplicationID(), *guildID)
+	}
+	// the commands are fetched with their raw JSON, as discord.ApplicationCommand can't tell a null default member permission from 0
+	var existing []existingCommand
+	if err := client.Rest().Do(endpoint, nil, &existing, cfg.RequestOpts...); err != nil {
+		return fmt.Errorf("failed to get existing commands: %w", err)
+	}
+
+	changes, unchanged, err := diffCommands(guildID, existing, commands)
+	if err != nil {
+		return err
+	}
+	report.Unchanged += unchanged
+	if cfg.DryRun {
+		report.Changes = append(report.Changes, changes...)
+		return nil
+	}
+
+	for _, change := range changes {
+		if err = applyCommandChange(client, change, cfg.RequestOpts); err != nil {
+			return fmt.Errorf("failed to %s: %w", change, err)
+		}
+		report.Changes = append(report.Changes, change)
+	}
+	return nil
+}
+
+func applyCommandChange(client bot.Client, change CommandChange, opts []rest.RequestOpt) error {
+	var err error
+	switch change.Type {
+	case CommandChangeTypeCreate:
+		if change.GuildID == nil {
+			_, err = client.Rest().CreateGlobalCommand(client.ApplicationID(), change.Command, opts...)
+		} else {
+			_, err = client.Rest().CreateGuildCommand(client.ApplicationID(), *change.GuildID, change.Command, opts...)
+		}
+	case CommandChangeTypeUpdate:
+		update := commandUpdate(change.Command)
+		if change.GuildID == nil {
+			_, err = client.Rest().UpdateGlobalCommand(client.ApplicationID(), change.CommandID, update, opts...)
+		} else {
+			_, err = client.Rest().UpdateGuildCommand(client.ApplicationID(), *change.GuildID, change.CommandID, update, opts...)
+		}
+	case CommandChangeTypeDelete:
+		if change.GuildID == nil {
+			err = client.Rest().DeleteGlobalCommand(client.ApplicationID(), change.CommandID, opts...)
+		} else {
+			err = client.Rest().DeleteGuildCommand(client.ApplicationID(), *change.GuildID, change.CommandID, opts...)
+		}
+	}
+	return err
+}
+
+// commandUpdate converts the discord.ApplicationCommandCreate to a discord.ApplicationCommandUpdate which sets every compared field,
+// so fields which were removed from the command are reset to the defaults of Discord.
+func commandUpdate(command discord.ApplicationCommandCreate) discord.ApplicationCommandUpdate {
+	switch c := command.(type) {
+	case discord.SlashCommandCreate:
+		options := c.Options
+		if options == nil {
+			options = []discord.ApplicationCommandOption{}
+		}
+		return discord.SlashCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			Description:              &c.Description,
+			DescriptionLocalizations: &c.DescriptionLocalizations,
+			Options:                  &options,
+			DefaultMemberPermissions: nullablePermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         integrationTypes(c.IntegrationTypes),
+			Contexts:                 &c.Contexts,
+			NSFW:                     json.Ptr(c.NSFW != nil && *c.NSFW),
+		}
+	case discord.UserCommandCreate:
+		return discord.UserCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: nullablePermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         integrationTypes(c.IntegrationTypes),
+			Contexts:                 &c.Contexts,
+			NSFW:                     json.Ptr(c.NSFW != nil && *c.NSFW),
+		}
+	case discord.MessageCommandCreate:
+		return discord.MessageCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: nullablePermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         integrationTypes(c.IntegrationTypes),
+			Contexts:                 &c.Contexts,
+			NSFW:                     json.Ptr(c.NSFW != nil && *c.NSFW),
+		}
+	case discord.EntryPointCommandCreate:
+		return discord.EntryPointCommandUpdate{
+			Name:                     &c.Name,
+			NameLocalizations:        &c.NameLocalizations,
+			DefaultMemberPermissions: nullablePermissions(c.DefaultMemberPermissions),
+			IntegrationTypes:         integrationTypes(c.IntegrationTypes),
+			Contexts:                 &c.Contexts,
+			NSFW:                     json.Ptr(c.NSFW != nil && *c.NSFW),
+			Handler:                  &c.Handler,
+		}
+	}
+	return nil
+}
+
+func nullablePermissions(permissions *json.Nullable[discord.Permissions]) *json.Nullable[discord.Permissions] {
+	if permissions == nil {
+		return json.NullPtr[discord.Permissions]()
+	}
+	return permissions
+}
+
+func integrationTypes(types []discord.ApplicationIntegrationType) *[]discord.ApplicationIntegrationType {
+	if len(types) == 0 {
+		types = []discord.ApplicationIntegrationType{discord.ApplicationIntegrationTypeGuildInstall}
+	}
+	return &types
+}
+
+// existingCommand is a command fetched from Discord together with the JSON it was decoded from.
+type existingCommand struct {
+	discord.ApplicationCommand
+	raw json.RawMessage
+}
+
+func (c *existingCommand) UnmarshalJSON(data []byte) error {
+	var v discord.UnmarshalApplicationCommand
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	c.ApplicationCommand = v.ApplicationCommand
+	c.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+type commandKey struct {
+	commandType discord.ApplicationCommandType
+	name        string
+}
+
+// diffCommands returns the changes needed to turn the existing commands into the given commands and the number of unchanged commands.
+func diffCommands(guildID *snowflake.ID, existing []existingCommand, commands []discord.ApplicationCommandCreate) ([]CommandChange, int, error) {
+	existingByKey := make(map[commandKey]existingCommand, len(existing))
+	for _, command := range existing {
+		existingByKey[commandKey{commandType: command.Type(), name: command.Name()}] = command
+	}
+
+	seen := make(map[commandKey]struct{}, len(commands))
+	for _, command := range commands {
+		seen[commandKey{commandType: command.Type(), name: command.CommandName()}] = struct{}{}
+	}
+
+	var (
+		changes   []CommandChange
+		unchanged int
+	)
+	// delete first, so removed commands don't count towards the command limit while creating new ones
+	for _, command := range existing {
+		key := commandKey{commandType: command.Type(), name: command.Name()}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		changes = append(changes, CommandChange{
+			Type:        CommandChangeTypeDelete,
+			GuildID:     guildID,
+			Name:        key.name,
+			CommandType: key.commandType,
+			CommandID:   command.ID(),
+		})
+	}
+
+	for _, command := range commands {
+		key := commandKey{commandType: command.Type(), name: command.CommandName()}
+		current, ok := existingByKey[key]
+		if !ok {
+			changes = append(changes, CommandChange{
+				Type:        CommandChangeTypeCreate,
+				GuildID:     guildID,
+				Name:        key.name,
+				CommandType: key.commandType,
+				Command:     command,
+			})
+			continue
+		}
+
+		equal, err := commandsEqual(current, command)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to compare command %s: %w", key.name, err)
+		}
+		if equal {
+			unchanged++
+			continue
+		}
+		changes = append(changes, CommandChange{
+			Type:        CommandChangeTypeUpdate,
+			GuildID:     guildID,
+			Name:        key.name,
+			CommandType: key.commandType,
+			CommandID:   current.ID(),
+			Command:     command,
+		})
+	}
+	return changes, unchanged, nil
+}
+
+// comparedCommandFields are the fields of a command which are compared by commandsEqual.
+var comparedCommandFields = []string{
+	"type",
+	"name",
+	"name_localizations",
+	"description",
+	"description_localizations",
+	"options",
+	"default_member_permissions",
+	"nsfw",
+	"integration_types",
+	"contexts",
+}
+
+// commandsEqual compares the existing command with the discord.ApplicationCommandCreate by their JSON representation with the defaults of Discord applied.
+func commandsEqual(existing existingCommand, command discord.ApplicationCommandCreate) (bool, error) {
+	a, err := normalizeCommand(existing.raw)
+	if err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(command)
+	if err != nil {
+		return false, err
+	}
+	b, err := normalizeCommand(data)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(a, b), nil
+}
+
+func normalizeCommand(data []byte) (map[string]any, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	normalized := make(map[string]any, len(comparedCommandFields))
+	for _, field := range comparedCommandFields {
+		normalized[field] = raw[field]
+	}
+	// commands without integration types are only installed to guilds
+	if types, _ := normalized["integration_types"].([]any); len(types) == 0 {
+		normalized["integration_types"] = []any{float64(discord.ApplicationIntegrationTypeGuildInstall)}
+	}
+	return pruneEmpty(normalized).(map[string]any), nil
+}
+
+// pruneEmpty recursively removes nil, false, empty strings, empty maps and empty slices from maps, as Discord omits them or treats them as their default.
+func pruneEmpty(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			value = pruneEmpty(value)
+			if isEmptyValue(value) {
+				delete(v, key)
+				continue
+			}
+			v[key] = value
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = pruneEmpty(value)
+		}
+		return v
+	}
+	return v
+}
+
+func isEmptyValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case map[string]any:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"github.com/disgoorg/disgo/rest"
+)
+
+// DefaultSyncConfig returns the default SyncConfig.
+func DefaultSyncConfig() *SyncConfig {
+	return &SyncConfig{}
+}
+
+// SyncConfig is the configuration of SyncCommandsIncremental.
+type SyncConfig struct {
+	DryRun      bool
+	RequestOpts []rest.RequestOpt
+}
+
+// SyncConfigOpt is a type alias for a function that takes a SyncConfig and is used to configure your SyncCommandsIncremental.
+type SyncConfigOpt func(config *SyncConfig)
+
+// Apply applies the given SyncConfigOpt(s) to the SyncConfig.
+func (c *SyncConfig) Apply(opts []SyncConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithDryRun only plans the changes without applying them. The planned changes are returned in the CommandSyncReport.
+func WithDryRun() SyncConfigOpt {
+	return func(config *SyncConfig) {
+		config.DryRun = true
+	}
+}
+
+// WithSyncRequestOpts sets the rest.RequestOpt(s) used for all requests of the sync.
+func WithSyncRequestOpts(opts ...rest.RequestOpt) SyncConfigOpt {
+	return func(config *SyncConfig) {
+		config.RequestOpts = append(config.RequestOpts, opts...)
+	}
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/disgotest"
+)
+
+func TestSyncCommandsIncremental(t *testing.T) {
+	server := disgotest.NewServer()
+	defer server.Close()
+
+	server.RespondRest(http.MethodGet, "/applications/{application.id}/commands", http.StatusOK, json.RawMessage(`[
+		{"id": "1", "type": 1, "application_id": "1000000000000000001", "name": "ping", "description": "Ping", "default_member_permissions": null, "dm_permission": true, "nsfw": false, "integration_types": [0], "contexts": null, "version": "1",
+		 "options": [{"type": 3, "name": "text", "description": "Text", "required": false}]},
+		{"id": "2", "type": 1, "application_id": "1000000000000000001", "name": "ban", "description": "Ban", "default_member_permissions": "4", "integration_types": [0], "version": "1"},
+		{"id": "3", "type": 2, "application_id": "1000000000000000001", "name": "info", "description": "", "integration_types": [0], "version": "1"}
+	]`))
+
+	server.HandleRest(http.MethodPost, "/applications/{application.id}/commands", func(rq disgotest.Request) (int, any) {
+		var command map[string]any
+		if err := rq.Unmarshal(&command); err != nil {
+			return http.StatusBadRequest, nil
+		}
+		command["id"] = "4"
+		return http.StatusOK, command
+	})
+
+	var update map[string]any
+	server.HandleRest(http.MethodPatch, "/applications/{application.id}/commands/{command.id}", func(rq disgotest.Request) (int, any) {
+		if err := rq.Unmarshal(&update); err != nil {
+			return http.StatusBadRequest, nil
+		}
+		command := map[string]any{"id": "2", "type": 1, "name": "ban", "description": "Ban a member"}
+		return http.StatusOK, command
+	})
+
+	client, err := disgo.New(server.Token(), server.ClientConfigOpts()...)
+	require.NoError(t, err)
+	defer client.Close(context.Background())
+
+	commands := []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:        "ping",
+			Description: "Ping",
+			Options:     []discord.ApplicationCommandOption{discord.ApplicationCommandOptionString{Name: "text", Description: "Text"}},
+		},
+		discord.SlashCommandCreate{
+			Name:                     "ban",
+			Description:              "Ban a member",
+			DefaultMemberPermissions: json.NewNullablePtr(discord.PermissionBanMembers),
+		},
+		discord.MessageCommandCreate{Name: "quote"},
+	}
+
+	report, err := SyncCommandsIncremental(client, commands, nil, WithDryRun())
+	require.NoError(t, err)
+	assert.Equal(t, "delete global user command info\nupdate global slash command ban\ncreate global message command quote\n3 changes, 1 unchanged", report.String())
+	for _, rq := range server.Requests() {
+		assert.Equal(t, http.MethodGet, rq.Method, "dry run must not change commands")
+	}
+
+	server.Reset()
+	_, err = SyncCommandsIncremental(client, commands, nil)
+	require.NoError(t, err)
+
+	var methods []string
+	for _, rq := range server.Requests() {
+		methods = append(methods, rq.Method+" "+rq.Path)
+	}
+	assert.Equal(t, []string{
+		"GET /applications/1000000000000000001/commands",
+		"DELETE /applications/1000000000000000001/commands/3",
+		"PATCH /applications/1000000000000000001/commands/2",
+		"POST /applications/1000000000000000001/commands",
+	}, methods)
+	assert.Equal(t, "Ban a member", update["description"])
+	assert.Equal(t, "4", update["default_member_permissions"])
+	assert.Equal(t, []any{}, update["options"], "removed options should be cleared")
+}
+
+func TestSyncCommandsIncrementalDefaultMemberPermissions(t *testing.T) {
+	server := disgotest.NewServer()
+	defer server.Close()
+
+	server.RespondRest(http.MethodGet, "/applications/{application.id}/commands", http.StatusOK, json.RawMessage(`[
+		{"id": "1", "type": 1, "application_id": "1000000000000000001", "name": "open", "description": "Open", "default_member_permissions": null, "version": "1"},
+		{"id": "2", "type": 1, "application_id": "1000000000000000001", "name": "admin", "description": "Admin", "default_member_permissions": "0", "version": "1"},
+		{"id": "3", "type": 1, "application_id": "1000000000000000001", "name": "unchanged", "description": "Unchanged", "default_member_permissions": "0", "version": "1"}
+	]`))
+
+	client, err := disgo.New(server.Token(), server.ClientConfigOpts()...)
+	require.NoError(t, err)
+	defer client.Close(context.Background())
+
+	report, err := SyncCommandsIncremental(client, []discord.ApplicationCommandCreate{
+		// only administrators can use the command now
+		discord.SlashCommandCreate{Name: "open", Description: "Open", DefaultMemberPermissions: json.NewNullablePtr(discord.Permissions(0))},
+		// everyone can use the command now
+		discord.SlashCommandCreate{Name: "admin", Description: "Admin"},
+		discord.SlashCommandCreate{Name: "unchanged", Description: "Unchanged", DefaultMemberPermissions: json.NewNullablePtr(discord.Permissions(0))},
+	}, nil, WithDryRun())
+	require.NoError(t, err)
+	assert.Equal(t, "update global slash command open\nupdate global slash command admin\n2 changes, 1 unchanged", report.String())
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/disgoorg/disgo/tracing"
+)
+
+func tracingEnabled(ctx context.Context) bool {
+	return tracing.TracerFromContext(ctx) != tracing.Noop
+}
+
+// traceHandler wraps the Handler in a tracing.Span. The Handler receives a copy of the InteractionEvent whose Ctx carries the tracing.Span,
+// so the InteractionEvent of the caller is never modified, even if the Handler keeps running in another goroutine.
+func traceHandler(name string, h Handler, attrs ...tracing.Attribute) Handler {
+	return func(event *InteractionEvent) error {
+		ctx, span := tracing.TracerFromContext(event.Ctx).Start(event.Ctx, name, attrs...)
+		defer span.End()
+
+		// the copy shares the route, acknowledgement & vars with the event
+		tracedEvent := *event
+		tracedEvent.Ctx = ctx
+		err := h(&tracedEvent)
+		span.RecordError(err)
+		return err
+	}
+}
+
+func middlewareName(m Middleware) string {
+	f := runtime.FuncForPC(reflect.ValueOf(m).Pointer())
+	if f == nil {
+		return "unknown"
+	}
+	name := f.Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/tracing"
+)
+
+func TestMuxTracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	restClient := rest.New(rest.NewClient("", rest.WithURL(server.URL), rest.WithRateLimiter(rest.NewNoopRateLimiter())))
+
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	require.NoError(t, err)
+	interaction, err := discord.UnmarshalInteraction(slashData)
+	require.NoError(t, err)
+
+	tracer := tracing.NewMemoryTracer()
+	mux := New()
+	mux.Tracer(tracer)
+	var wg sync.WaitGroup
+	mux.Use(func(next Handler) Handler {
+		return func(e *InteractionEvent) error {
+			ctx := e.Ctx
+			wg.Add(1)
+			// like middleware.Go, the event keeps being used after this middleware returned
+			go func() {
+				defer wg.Done()
+				_ = next(e)
+			}()
+			assert.Equal(t, ctx, e.Ctx, "the Ctx of the event should not be modified")
+			return nil
+		}
+	})
+	mux.SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{Content: "bar"})
+	})
+
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(nil, 0, 0),
+		Interaction:  interaction,
+		Respond: func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+			return restClient.CreateInteractionResponse(interaction.ID(), interaction.Token(), discord.InteractionResponse{Type: responseType, Data: data}, opts...)
+		},
+	})
+
+	wg.Wait()
+
+	spans := map[string]tracing.RecordedSpan{}
+	for _, span := range tracer.Spans() {
+		spans[span.Name] = span
+	}
+	require.Len(t, spans, 6)
+
+	root := spans["handler.interaction"]
+	assert.Zero(t, root.ParentID)
+	path, _ := root.Attribute("interaction.path")
+	assert.Equal(t, "/foo", path)
+
+	for child, parent := range map[string]string{
+		"handler.mux":          "handler.interaction",
+		"handler.middleware":   "handler.mux",
+		"handler.handle":       "handler.middleware",
+		"rest.request":         "handler.handle",
+		"rest.rate_limit_wait": "rest.request",
+	} {
+		assert.Equal(t, root.TraceID, spans[child].TraceID, child)
+		assert.Equal(t, spans[parent].SpanID, spans[child].ParentID, child)
+	}
+
+	status, _ := spans["rest.request"].Attribute("http.status_code")
+	assert.Equal(t, http.StatusNoContent, status)
+}
@@ -75,10 +75,12 @@ func gatewayHandlerGuildCreate(client bot.Client, sequenceNumber int, shardID in
 
 	if wasUnready {
 		client.Caches().SetGuildUnready(event.ID, false)
-		client.EventManager().DispatchEvent(&events.GuildReady{
-			GenericGuild: genericGuildEvent,
-		})
-		if len(client.Caches().UnreadyGuildIDs()) == 0 {
+		if bot.IsListening[*events.GuildReady](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildReady{
+				GenericGuild: genericGuildEvent,
+			})
+		}
+		if len(client.Caches().UnreadyGuildIDs()) == 0 && bot.IsListening[*events.GuildsReady](client.EventManager()) {
 			client.EventManager().DispatchEvent(&events.GuildsReady{
 				GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
 			})
@@ -95,10 +97,12 @@ func gatewayHandlerGuildCreate(client bot.Client, sequenceNumber int, shardID in
 	}
 	if wasUnavailable {
 		client.Caches().SetGuildUnavailable(event.ID, false)
-		client.EventManager().DispatchEvent(&events.GuildAvailable{
-			GenericGuild: genericGuildEvent,
-		})
-	} else {
+		if bot.IsListening[*events.GuildAvailable](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildAvailable{
+				GenericGuild: genericGuildEvent,
+			})
+		}
+	} else if bot.IsListening[*events.GuildJoin](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildJoin{
 			GenericGuild: genericGuildEvent,
 		})
@@ -109,13 +113,15 @@ func gatewayHandlerGuildUpdate(client bot.Client, sequenceNumber int, shardID in
 	oldGuild, _ := client.Caches().Guild(event.ID)
 	client.Caches().AddGuild(event.Guild)
 
-	client.EventManager().DispatchEvent(&events.GuildUpdate{
-		GenericGuild: &events.GenericGuild{
-			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
-			Guild:        event.Guild,
-		},
-		OldGuild: oldGuild,
-	})
+	if bot.IsListening[*events.GuildUpdate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildUpdate{
+			GenericGuild: &events.GenericGuild{
+				GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
+				Guild:        event.Guild,
+			},
+			OldGuild: oldGuild,
+		})
+	}
 }
 
 func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildDelete) {
@@ -149,10 +155,12 @@ func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID in
 	}
 
 	if event.Unavailable {
-		client.EventManager().DispatchEvent(&events.GuildUnavailable{
-			GenericGuild: genericGuildEvent,
-		})
-	} else {
+		if bot.IsListening[*events.GuildUnavailable](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildUnavailable{
+				GenericGuild: genericGuildEvent,
+			})
+		}
+	} else if bot.IsListening[*events.GuildLeave](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildLeave{
 			GenericGuild: genericGuildEvent,
 		})
@@ -160,9 +168,11 @@ func gatewayHandlerGuildDelete(client bot.Client, sequenceNumber int, shardID in
 }
 
 func gatewayHandlerGuildAuditLogEntryCreate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildAuditLogEntryCreate) {
-	client.EventManager().DispatchEvent(&events.GuildAuditLogEntryCreate{
-		GenericEvent:  events.NewGenericEvent(client, sequenceNumber, shardID),
-		GuildID:       event.GuildID,
-		AuditLogEntry: event.AuditLogEntry,
-	})
+	if bot.IsListening[*events.GuildAuditLogEntryCreate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildAuditLogEntryCreate{
+			GenericEvent:  events.NewGenericEvent(client, sequenceNumber, shardID),
+			GuildID:       event.GuildID,
+			AuditLogEntry: event.AuditLogEntry,
+		})
+	}
 }
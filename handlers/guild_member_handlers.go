@@ -14,27 +14,31 @@ func gatewayHandlerGuildMemberAdd(client bot.Client, sequenceNumber int, shardID
 
 	client.Caches().AddMember(event.Member)
 
-	client.EventManager().DispatchEvent(&events.GuildMemberJoin{
-		GenericGuildMember: &events.GenericGuildMember{
-			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
-			GuildID:      event.GuildID,
-			Member:       event.Member,
-		},
-	})
+	if bot.IsListening[*events.GuildMemberJoin](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildMemberJoin{
+			GenericGuildMember: &events.GenericGuildMember{
+				GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
+				GuildID:      event.GuildID,
+				Member:       event.Member,
+			},
+		})
+	}
 }
 
 func gatewayHandlerGuildMemberUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildMemberUpdate) {
 	oldMember, _ := client.Caches().Member(event.GuildID, event.User.ID)
 	client.Caches().AddMember(event.Member)
 
-	client.EventManager().DispatchEvent(&events.GuildMemberUpdate{
-		GenericGuildMember: &events.GenericGuildMember{
-			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
-			GuildID:      event.GuildID,
-			Member:       event.Member,
-		},
-		OldMember: oldMember,
-	})
+	if bot.IsListening[*events.GuildMemberUpdate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildMemberUpdate{
+			GenericGuildMember: &events.GenericGuildMember{
+				GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
+				GuildID:      event.GuildID,
+				Member:       event.Member,
+			},
+			OldMember: oldMember,
+		})
+	}
 }
 
 func gatewayHandlerGuildMemberRemove(client bot.Client, sequenceNumber int, shardID int, event gateway.EventGuildMemberRemove) {
@@ -45,12 +49,14 @@ func gatewayHandlerGuildMemberRemove(client bot.Client, sequenceNumber int, shar
 
 	member, _ := client.Caches().RemoveMember(event.GuildID, event.User.ID)
 
-	client.EventManager().DispatchEvent(&events.GuildMemberLeave{
-		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
-		GuildID:      event.GuildID,
-		User:         event.User,
-		Member:       member,
-	})
+	if bot.IsListening[*events.GuildMemberLeave](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildMemberLeave{
+			GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
+			GuildID:      event.GuildID,
+			User:         event.User,
+			Member:       member,
+		})
+	}
 }
 
 func gatewayHandlerGuildMembersChunk(client bot.Client, _ int, _ int, event gateway.EventGuildMembersChunk) {
@@ -32,26 +32,30 @@ func gatewayHandlerMessageCreate(client bot.Client, sequenceNumber int, shardID
 	}
 
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
-	client.EventManager().DispatchEvent(&events.MessageCreate{
-		GenericMessage: &events.GenericMessage{
-			GenericEvent: genericEvent,
-			MessageID:    event.ID,
-			Message:      event.Message,
-			ChannelID:    event.ChannelID,
-			GuildID:      event.GuildID,
-		},
-	})
-
-	if event.GuildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageCreate{
-			GenericDMMessage: &events.GenericDMMessage{
+	if bot.IsListening[*events.MessageCreate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageCreate{
+			GenericMessage: &events.GenericMessage{
 				GenericEvent: genericEvent,
 				MessageID:    event.ID,
 				Message:      event.Message,
 				ChannelID:    event.ChannelID,
+				GuildID:      event.GuildID,
 			},
 		})
-	} else {
+	}
+
+	if event.GuildID == nil {
+		if bot.IsListening[*events.DMMessageCreate](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageCreate{
+				GenericDMMessage: &events.GenericDMMessage{
+					GenericEvent: genericEvent,
+					MessageID:    event.ID,
+					Message:      event.Message,
+					ChannelID:    event.ChannelID,
+				},
+			})
+		}
+	} else if bot.IsListening[*events.GuildMessageCreate](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildMessageCreate{
 			GenericGuildMessage: &events.GenericGuildMessage{
 				GenericEvent: genericEvent,
@@ -69,28 +73,32 @@ func gatewayHandlerMessageUpdate(client bot.Client, sequenceNumber int, shardID
 	client.Caches().AddMessage(event.Message)
 
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
-	client.EventManager().DispatchEvent(&events.MessageUpdate{
-		GenericMessage: &events.GenericMessage{
-			GenericEvent: genericEvent,
-			MessageID:    event.ID,
-			Message:      event.Message,
-			ChannelID:    event.ChannelID,
-			GuildID:      event.GuildID,
-		},
-		OldMessage: oldMessage,
-	})
-
-	if event.GuildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageUpdate{
-			GenericDMMessage: &events.GenericDMMessage{
+	if bot.IsListening[*events.MessageUpdate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageUpdate{
+			GenericMessage: &events.GenericMessage{
 				GenericEvent: genericEvent,
 				MessageID:    event.ID,
 				Message:      event.Message,
 				ChannelID:    event.ChannelID,
+				GuildID:      event.GuildID,
 			},
 			OldMessage: oldMessage,
 		})
-	} else {
+	}
+
+	if event.GuildID == nil {
+		if bot.IsListening[*events.DMMessageUpdate](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageUpdate{
+				GenericDMMessage: &events.GenericDMMessage{
+					GenericEvent: genericEvent,
+					MessageID:    event.ID,
+					Message:      event.Message,
+					ChannelID:    event.ChannelID,
+				},
+				OldMessage: oldMessage,
+			})
+		}
+	} else if bot.IsListening[*events.GuildMessageUpdate](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildMessageUpdate{
 			GenericGuildMessage: &events.GenericGuildMessage{
 				GenericEvent: genericEvent,
@@ -126,26 +134,30 @@ func handleMessageDelete(client bot.Client, sequenceNumber int, shardID int, mes
 		client.Caches().AddChannel(channel)
 	}
 
-	client.EventManager().DispatchEvent(&events.MessageDelete{
-		GenericMessage: &events.GenericMessage{
-			GenericEvent: genericEvent,
-			MessageID:    messageID,
-			Message:      message,
-			ChannelID:    channelID,
-			GuildID:      guildID,
-		},
-	})
-
-	if guildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageDelete{
-			GenericDMMessage: &events.GenericDMMessage{
+	if bot.IsListening[*events.MessageDelete](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageDelete{
+			GenericMessage: &events.GenericMessage{
 				GenericEvent: genericEvent,
 				MessageID:    messageID,
 				Message:      message,
 				ChannelID:    channelID,
+				GuildID:      guildID,
 			},
 		})
-	} else {
+	}
+
+	if guildID == nil {
+		if bot.IsListening[*events.DMMessageDelete](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageDelete{
+				GenericDMMessage: &events.GenericDMMessage{
+					GenericEvent: genericEvent,
+					MessageID:    messageID,
+					Message:      message,
+					ChannelID:    channelID,
+				},
+			})
+		}
+	} else if bot.IsListening[*events.GuildMessageDelete](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildMessageDelete{
 			GenericGuildMessage: &events.GenericGuildMessage{
 				GenericEvent: genericEvent,
@@ -10,85 +10,95 @@ import (
 func gatewayHandlerMessageReactionAdd(client bot.Client, sequenceNumber int, shardID int, event gateway.EventMessageReactionAdd) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
-	client.EventManager().DispatchEvent(&events.MessageReactionAdd{
-		GenericReaction: &events.GenericReaction{
-			GenericEvent: genericEvent,
-			MessageID:    event.MessageID,
-			ChannelID:    event.ChannelID,
-			GuildID:      event.GuildID,
-			UserID:       event.UserID,
-			Emoji:        event.Emoji,
-			BurstColors:  event.BurstColors,
-			Burst:        event.Burst,
-		},
-		Member: event.Member,
-	})
-
-	if event.GuildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageReactionAdd{
-			GenericDMMessageReaction: &events.GenericDMMessageReaction{
+	if bot.IsListening[*events.MessageReactionAdd](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageReactionAdd{
+			GenericReaction: &events.GenericReaction{
 				GenericEvent: genericEvent,
 				MessageID:    event.MessageID,
 				ChannelID:    event.ChannelID,
+				GuildID:      event.GuildID,
 				UserID:       event.UserID,
 				Emoji:        event.Emoji,
 				BurstColors:  event.BurstColors,
 				Burst:        event.Burst,
 			},
-			MessageAuthorID: event.MessageAuthorID,
+			Member: event.Member,
 		})
+	}
+
+	if event.GuildID == nil {
+		if bot.IsListening[*events.DMMessageReactionAdd](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageReactionAdd{
+				GenericDMMessageReaction: &events.GenericDMMessageReaction{
+					GenericEvent: genericEvent,
+					MessageID:    event.MessageID,
+					ChannelID:    event.ChannelID,
+					UserID:       event.UserID,
+					Emoji:        event.Emoji,
+					BurstColors:  event.BurstColors,
+					Burst:        event.Burst,
+				},
+				MessageAuthorID: event.MessageAuthorID,
+			})
+		}
 	} else {
 		var member discord.Member
 		// sometimes the member is nil for some reason
 		if event.Member != nil {
 			member = *event.Member
 		}
-		client.EventManager().DispatchEvent(&events.GuildMessageReactionAdd{
-			GenericGuildMessageReaction: &events.GenericGuildMessageReaction{
-				GenericEvent: genericEvent,
-				MessageID:    event.MessageID,
-				ChannelID:    event.ChannelID,
-				GuildID:      *event.GuildID,
-				UserID:       event.UserID,
-				Emoji:        event.Emoji,
-				BurstColors:  event.BurstColors,
-				Burst:        event.Burst,
-			},
-			Member:          member,
-			MessageAuthorID: event.MessageAuthorID,
-		})
+		if bot.IsListening[*events.GuildMessageReactionAdd](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildMessageReactionAdd{
+				GenericGuildMessageReaction: &events.GenericGuildMessageReaction{
+					GenericEvent: genericEvent,
+					MessageID:    event.MessageID,
+					ChannelID:    event.ChannelID,
+					GuildID:      *event.GuildID,
+					UserID:       event.UserID,
+					Emoji:        event.Emoji,
+					BurstColors:  event.BurstColors,
+					Burst:        event.Burst,
+				},
+				Member:          member,
+				MessageAuthorID: event.MessageAuthorID,
+			})
+		}
 	}
 }
 
 func gatewayHandlerMessageReactionRemove(client bot.Client, sequenceNumber int, shardID int, event gateway.EventMessageReactionRemove) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
-	client.EventManager().DispatchEvent(&events.MessageReactionRemove{
-		GenericReaction: &events.GenericReaction{
-			GenericEvent: genericEvent,
-			MessageID:    event.MessageID,
-			ChannelID:    event.ChannelID,
-			GuildID:      event.GuildID,
-			UserID:       event.UserID,
-			Emoji:        event.Emoji,
-			BurstColors:  event.BurstColors,
-			Burst:        event.Burst,
-		},
-	})
-
-	if event.GuildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageReactionRemove{
-			GenericDMMessageReaction: &events.GenericDMMessageReaction{
+	if bot.IsListening[*events.MessageReactionRemove](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageReactionRemove{
+			GenericReaction: &events.GenericReaction{
 				GenericEvent: genericEvent,
 				MessageID:    event.MessageID,
 				ChannelID:    event.ChannelID,
+				GuildID:      event.GuildID,
 				UserID:       event.UserID,
 				Emoji:        event.Emoji,
 				BurstColors:  event.BurstColors,
 				Burst:        event.Burst,
 			},
 		})
-	} else {
+	}
+
+	if event.GuildID == nil {
+		if bot.IsListening[*events.DMMessageReactionRemove](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageReactionRemove{
+				GenericDMMessageReaction: &events.GenericDMMessageReaction{
+					GenericEvent: genericEvent,
+					MessageID:    event.MessageID,
+					ChannelID:    event.ChannelID,
+					UserID:       event.UserID,
+					Emoji:        event.Emoji,
+					BurstColors:  event.BurstColors,
+					Burst:        event.Burst,
+				},
+			})
+		}
+	} else if bot.IsListening[*events.GuildMessageReactionRemove](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildMessageReactionRemove{
 			GenericGuildMessageReaction: &events.GenericGuildMessageReaction{
 				GenericEvent: genericEvent,
@@ -107,20 +117,24 @@ func gatewayHandlerMessageReactionRemove(client bot.Client, sequenceNumber int,
 func gatewayHandlerMessageReactionRemoveAll(client bot.Client, sequenceNumber int, shardID int, event gateway.EventMessageReactionRemoveAll) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
-	client.EventManager().DispatchEvent(&events.MessageReactionRemoveAll{
-		GenericEvent: genericEvent,
-		MessageID:    event.MessageID,
-		ChannelID:    event.ChannelID,
-		GuildID:      event.GuildID,
-	})
-
-	if event.GuildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageReactionRemoveAll{
+	if bot.IsListening[*events.MessageReactionRemoveAll](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageReactionRemoveAll{
 			GenericEvent: genericEvent,
 			MessageID:    event.MessageID,
 			ChannelID:    event.ChannelID,
+			GuildID:      event.GuildID,
 		})
-	} else {
+	}
+
+	if event.GuildID == nil {
+		if bot.IsListening[*events.DMMessageReactionRemoveAll](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageReactionRemoveAll{
+				GenericEvent: genericEvent,
+				MessageID:    event.MessageID,
+				ChannelID:    event.ChannelID,
+			})
+		}
+	} else if bot.IsListening[*events.GuildMessageReactionRemoveAll](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildMessageReactionRemoveAll{
 			GenericEvent: genericEvent,
 			MessageID:    event.MessageID,
@@ -133,22 +147,26 @@ func gatewayHandlerMessageReactionRemoveAll(client bot.Client, sequenceNumber in
 func gatewayHandlerMessageReactionRemoveEmoji(client bot.Client, sequenceNumber int, shardID int, event gateway.EventMessageReactionRemoveEmoji) {
 	genericEvent := events.NewGenericEvent(client, sequenceNumber, shardID)
 
-	client.EventManager().DispatchEvent(&events.MessageReactionRemoveEmoji{
-		GenericEvent: genericEvent,
-		MessageID:    event.MessageID,
-		ChannelID:    event.ChannelID,
-		GuildID:      event.GuildID,
-		Emoji:        event.Emoji,
-	})
-
-	if event.GuildID == nil {
-		client.EventManager().DispatchEvent(&events.DMMessageReactionRemoveEmoji{
+	if bot.IsListening[*events.MessageReactionRemoveEmoji](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.MessageReactionRemoveEmoji{
 			GenericEvent: genericEvent,
 			MessageID:    event.MessageID,
 			ChannelID:    event.ChannelID,
+			GuildID:      event.GuildID,
 			Emoji:        event.Emoji,
 		})
-	} else {
+	}
+
+	if event.GuildID == nil {
+		if bot.IsListening[*events.DMMessageReactionRemoveEmoji](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.DMMessageReactionRemoveEmoji{
+				GenericEvent: genericEvent,
+				MessageID:    event.MessageID,
+				ChannelID:    event.ChannelID,
+				Emoji:        event.Emoji,
+			})
+		}
+	} else if bot.IsListening[*events.GuildMessageReactionRemoveEmoji](client.EventManager()) {
 		client.EventManager().DispatchEvent(&events.GuildMessageReactionRemoveEmoji{
 			GenericEvent: genericEvent,
 			MessageID:    event.MessageID,
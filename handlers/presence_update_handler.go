@@ -55,6 +55,13 @@ func gatewayHandlerPresenceUpdate(client bot.Client, sequenceNumber int, shardID
 		})
 	}
 
+	// comparing activities is expensive, so skip it if nobody listens to activity events
+	if !bot.IsListening[*events.UserActivityStart](client.EventManager()) &&
+		!bot.IsListening[*events.UserActivityStop](client.EventManager()) &&
+		!bot.IsListening[*events.UserActivityUpdate](client.EventManager()) {
+		return
+	}
+
 	genericUserActivityEvent := events.GenericUserActivity{
 		GenericEvent: genericEvent,
 		UserID:       event.PresenceUser.ID,
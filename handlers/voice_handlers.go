@@ -7,10 +7,12 @@ import (
 )
 
 func gatewayHandlerVoiceChannelEffectSend(client bot.Client, sequenceNumber int, shardID int, event gateway.EventVoiceChannelEffectSend) {
-	client.EventManager().DispatchEvent(&events.GuildVoiceChannelEffectSend{
-		GenericEvent:                events.NewGenericEvent(client, sequenceNumber, shardID),
-		EventVoiceChannelEffectSend: event,
-	})
+	if bot.IsListening[*events.GuildVoiceChannelEffectSend](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildVoiceChannelEffectSend{
+			GenericEvent:                events.NewGenericEvent(client, sequenceNumber, shardID),
+			EventVoiceChannelEffectSend: event,
+		})
+	}
 }
 
 func gatewayHandlerVoiceStateUpdate(client bot.Client, sequenceNumber int, shardID int, event gateway.EventVoiceStateUpdate) {
@@ -34,25 +36,33 @@ func gatewayHandlerVoiceStateUpdate(client bot.Client, sequenceNumber int, shard
 		Member:       member,
 	}
 
-	client.EventManager().DispatchEvent(&events.GuildVoiceStateUpdate{
-		GenericGuildVoiceState: genericGuildVoiceEvent,
-		OldVoiceState:          oldVoiceState,
-	})
-
-	if oldOk && oldVoiceState.ChannelID != nil && event.ChannelID != nil {
-		client.EventManager().DispatchEvent(&events.GuildVoiceMove{
+	if bot.IsListening[*events.GuildVoiceStateUpdate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.GuildVoiceStateUpdate{
 			GenericGuildVoiceState: genericGuildVoiceEvent,
 			OldVoiceState:          oldVoiceState,
 		})
+	}
+
+	if oldOk && oldVoiceState.ChannelID != nil && event.ChannelID != nil {
+		if bot.IsListening[*events.GuildVoiceMove](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildVoiceMove{
+				GenericGuildVoiceState: genericGuildVoiceEvent,
+				OldVoiceState:          oldVoiceState,
+			})
+		}
 	} else if (oldOk || oldVoiceState.ChannelID == nil) && event.ChannelID != nil {
-		client.EventManager().DispatchEvent(&events.GuildVoiceJoin{
-			GenericGuildVoiceState: genericGuildVoiceEvent,
-		})
+		if bot.IsListening[*events.GuildVoiceJoin](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildVoiceJoin{
+				GenericGuildVoiceState: genericGuildVoiceEvent,
+			})
+		}
 	} else if event.ChannelID == nil {
-		client.EventManager().DispatchEvent(&events.GuildVoiceLeave{
-			GenericGuildVoiceState: genericGuildVoiceEvent,
-			OldVoiceState:          oldVoiceState,
-		})
+		if bot.IsListening[*events.GuildVoiceLeave](client.EventManager()) {
+			client.EventManager().DispatchEvent(&events.GuildVoiceLeave{
+				GenericGuildVoiceState: genericGuildVoiceEvent,
+				OldVoiceState:          oldVoiceState,
+			})
+		}
 	} else {
 		client.Logger().Warn("could not decide which GuildVoice to fire")
 	}
@@ -63,8 +73,10 @@ func gatewayHandlerVoiceServerUpdate(client bot.Client, sequenceNumber int, shar
 		client.VoiceManager().HandleVoiceServerUpdate(event)
 	}
 
-	client.EventManager().DispatchEvent(&events.VoiceServerUpdate{
-		GenericEvent:           events.NewGenericEvent(client, sequenceNumber, shardID),
-		EventVoiceServerUpdate: event,
-	})
+	if bot.IsListening[*events.VoiceServerUpdate](client.EventManager()) {
+		client.EventManager().DispatchEvent(&events.VoiceServerUpdate{
+			GenericEvent:           events.NewGenericEvent(client, sequenceNumber, shardID),
+			EventVoiceServerUpdate: event,
+		})
+	}
 }
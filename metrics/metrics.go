@@ -0,0 +1,51 @@
+// Package metrics provides an optional instrumentation interface for the gateway, rest, cache and handler packages of disgo
+// and a PrometheusExporter which exposes the collected metrics in the Prometheus text format.
+package metrics
+
+import (
+	"time"
+)
+
+// Recorder receives measurements from the different disgo components.
+// Implementations must be safe for concurrent use and should return quickly, as they are called on hot paths.
+type Recorder interface {
+	// GatewayEventReceived is called for every dispatch event received by the gateway.Gateway with the given shard ID.
+	GatewayEventReceived(shardID int, eventType string)
+
+	// GatewayHeartbeatLatency is called with the latency between a heartbeat and its acknowledgement.
+	GatewayHeartbeatLatency(shardID int, latency time.Duration)
+
+	// GatewayReconnect is called every time the gateway.Gateway with the given shard ID tries to reconnect.
+	GatewayReconnect(shardID int)
+
+	// RestRequest is called for every request sent by the rest.Client. The route is the unformatted route of the rest.Endpoint.
+	// The status is 0 if no response was received.
+	RestRequest(method string, route string, status int, duration time.Duration)
+
+	// RestRateLimitWait is called with the time a request waited for the rest.RateLimiter before being sent.
+	RestRateLimitWait(method string, route string, wait time.Duration)
+
+	// RestRateLimited is called for every 429 response received by the rest.Client.
+	RestRateLimited(method string, route string, global bool)
+
+	// RegisterCacheSizes registers a function which returns the number of cached entities per cache type.
+	// It is called whenever the sizes are needed, for example on every scrape.
+	RegisterCacheSizes(sizes func() map[string]int)
+
+	// HandlerCompleted is called after a handler.Mux handler for the given interaction type and route returned.
+	HandlerCompleted(interactionType string, route string, duration time.Duration, err error)
+}
+
+// Noop is a Recorder which discards all measurements.
+var Noop Recorder = noopRecorder{}
+
+type noopRecorder struct{}
+
+func (noopRecorder) GatewayEventReceived(int, string)                      {}
+func (noopRecorder) GatewayHeartbeatLatency(int, time.Duration)            {}
+func (noopRecorder) GatewayReconnect(int)                                  {}
+func (noopRecorder) RestRequest(string, string, int, time.Duration)        {}
+func (noopRecorder) RestRateLimitWait(string, string, time.Duration)       {}
+func (noopRecorder) RestRateLimited(string, string, bool)                  {}
+func (noopRecorder) RegisterCacheSizes(func() map[string]int)              {}
+func (noopRecorder) HandlerCompleted(string, string, time.Duration, error) {}
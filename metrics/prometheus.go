@@ -0,0 +1,308 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ Recorder     = (*PrometheusExporter)(nil)
+	_ http.Handler = (*PrometheusExporter)(nil)
+)
+
+// NewPrometheusExporter returns a new PrometheusExporter with the given PrometheusConfigOpt(s) applied.
+func NewPrometheusExporter(opts ...PrometheusConfigOpt) *PrometheusExporter {
+	cfg := DefaultPrometheusConfig()
+	cfg.Apply(opts)
+
+	buckets := slices.Clone(cfg.Buckets)
+	slices.Sort(buckets)
+
+	name := func(name string) string {
+		if cfg.Namespace == "" {
+			return name
+		}
+		return cfg.Namespace + "_" + name
+	}
+	return &PrometheusExporter{
+		gatewayEvents:           newFamily(name("gateway_events_total"), "Number of dispatch events received by the gateway.", "counter", nil, "shard_id", "event_type"),
+		gatewayHeartbeatLatency: newFamily(name("gateway_heartbeat_latency_seconds"), "Latency of the last acknowledged heartbeat.", "gauge", nil, "shard_id"),
+		gatewayReconnects:       newFamily(name("gateway_reconnects_total"), "Number of gateway reconnect attempts.", "counter", nil, "shard_id"),
+		restRequests:            newFamily(name("rest_requests_total"), "Number of rest requests by route and status.", "counter", nil, "method", "route", "status"),
+		restRequestDuration:     newFamily(name("rest_request_duration_seconds"), "Duration of rest requests excluding rate limit waits.", "histogram", buckets, "method", "route"),
+		restRateLimitWait:       newFamily(name("rest_rate_limit_wait_seconds"), "Time rest requests waited for the rate limiter.", "histogram", buckets, "method", "route"),
+		restRateLimited:         newFamily(name("rest_rate_limited_total"), "Number of 429 responses received.", "counter", nil, "method", "route", "global"),
+		cacheEntities:           newFamily(name("cache_entities"), "Number of cached entities by cache type.", "gauge", nil, "cache"),
+		handlerDuration:         newFamily(name("handler_duration_seconds"), "Duration of handler.Mux handlers.", "histogram", buckets, "type", "route"),
+		handlerErrors:           newFamily(name("handler_errors_total"), "Number of handler.Mux handlers which returned an error.", "counter", nil, "type", "route"),
+	}
+}
+
+// PrometheusExporter is a Recorder which keeps all measurements in memory and serves them in the Prometheus text exposition format.
+// Register it as an http.Handler on the path scraped by Prometheus, for example "/metrics".
+type PrometheusExporter struct {
+	gatewayEvents           *family
+	gatewayHeartbeatLatency *family
+	gatewayReconnects       *family
+	restRequests            *family
+	restRequestDuration     *family
+	restRateLimitWait       *family
+	restRateLimited         *family
+	cacheEntities           *family
+	handlerDuration         *family
+	handlerErrors           *family
+
+	cacheSizesMu sync.Mutex
+	cacheSizes   []func() map[string]int
+}
+
+func (e *PrometheusExporter) GatewayEventReceived(shardID int, eventType string) {
+	e.gatewayEvents.add(1, strconv.Itoa(shardID), eventType)
+}
+
+func (e *PrometheusExporter) GatewayHeartbeatLatency(shardID int, latency time.Duration) {
+	e.gatewayHeartbeatLatency.set(latency.Seconds(), strconv.Itoa(shardID))
+}
+
+func (e *PrometheusExporter) GatewayReconnect(shardID int) {
+	e.gatewayReconnects.add(1, strconv.Itoa(shardID))
+}
+
+func (e *PrometheusExporter) RestRequest(method string, route string, status int, duration time.Duration) {
+	e.restRequests.add(1, method, route, strconv.Itoa(status))
+	e.restRequestDuration.observe(duration.Seconds(), method, route)
+}
+
+func (e *PrometheusExporter) RestRateLimitWait(method string, route string, wait time.Duration) {
+	e.restRateLimitWait.observe(wait.Seconds(), method, route)
+}
+
+func (e *PrometheusExporter) RestRateLimited(method string, route string, global bool) {
+	e.restRateLimited.add(1, method, route, strconv.FormatBool(global))
+}
+
+func (e *PrometheusExporter) RegisterCacheSizes(sizes func() map[string]int) {
+	e.cacheSizesMu.Lock()
+	defer e.cacheSizesMu.Unlock()
+	e.cacheSizes = append(e.cacheSizes, sizes)
+}
+
+func (e *PrometheusExporter) HandlerCompleted(interactionType string, route string, duration time.Duration, err error) {
+	e.handlerDuration.observe(duration.Seconds(), interactionType, route)
+	if err != nil {
+		e.handlerErrors.add(1, interactionType, route)
+	}
+}
+
+// WriteTo writes all metrics in the Prometheus text exposition format to w.
+func (e *PrometheusExporter) WriteTo(w io.Writer) (int64, error) {
+	e.collectCacheSizes()
+
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	for _, f := range []*family{
+		e.gatewayEvents,
+		e.gatewayHeartbeatLatency,
+		e.gatewayReconnects,
+		e.restRequests,
+		e.restRequestDuration,
+		e.restRateLimitWait,
+		e.restRateLimited,
+		e.cacheEntities,
+		e.handlerDuration,
+		e.handlerErrors,
+	} {
+		f.write(cw)
+	}
+	if cw.err == nil {
+		cw.err = cw.w.Flush()
+	}
+	return cw.n, cw.err
+}
+
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = e.WriteTo(w)
+}
+
+func (e *PrometheusExporter) collectCacheSizes() {
+	e.cacheSizesMu.Lock()
+	funcs := slices.Clone(e.cacheSizes)
+	e.cacheSizesMu.Unlock()
+	if len(funcs) == 0 {
+		return
+	}
+
+	totals := map[string]int{}
+	for _, f := range funcs {
+		for cache, size := range f() {
+			totals[cache] += size
+		}
+	}
+	e.cacheEntities.reset()
+	for cache, size := range totals {
+		e.cacheEntities.set(float64(size), cache)
+	}
+}
+
+func newFamily(name string, help string, typ string, buckets []float64, labels ...string) *family {
+	return &family{
+		name:    name,
+		help:    help,
+		typ:     typ,
+		labels:  labels,
+		buckets: buckets,
+		series:  map[string]*series{},
+	}
+}
+
+type family struct {
+	name    string
+	help    string
+	typ     string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	// bucketCounts are the non-cumulative counts per bucket of histograms
+	bucketCounts []uint64
+	count        uint64
+}
+
+func (f *family) get(labelValues []string) *series {
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labelValues: labelValues}
+		if f.typ == "histogram" {
+			s.bucketCounts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+func (f *family) add(v float64, labelValues ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.get(labelValues).value += v
+}
+
+func (f *family) set(v float64, labelValues ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.get(labelValues).value = v
+}
+
+func (f *family) observe(v float64, labelValues ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := f.get(labelValues)
+	s.value += v
+	s.count++
+	if i, _ := slices.BinarySearch(f.buckets, v); i < len(f.buckets) {
+		s.bucketCounts[i]++
+	}
+}
+
+func (f *family) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.series)
+}
+
+func (f *family) write(w *countingWriter) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.series) == 0 {
+		return
+	}
+
+	w.WriteString("# HELP " + f.name + " " + f.help + "\n")
+	w.WriteString("# TYPE " + f.name + " " + f.typ + "\n")
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		labels := f.formatLabels(s.labelValues)
+		if f.typ != "histogram" {
+			w.WriteString(f.name + wrapLabels(labels) + " " + formatFloat(s.value) + "\n")
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.bucketCounts[i]
+			w.WriteString(f.name + "_bucket" + wrapLabels(appendLabel(labels, "le", formatFloat(bound))) + " " + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		w.WriteString(f.name + "_bucket" + wrapLabels(appendLabel(labels, "le", "+Inf")) + " " + strconv.FormatUint(s.count, 10) + "\n")
+		w.WriteString(f.name + "_sum" + wrapLabels(labels) + " " + formatFloat(s.value) + "\n")
+		w.WriteString(f.name + "_count" + wrapLabels(labels) + " " + strconv.FormatUint(s.count, 10) + "\n")
+	}
+}
+
+func (f *family) formatLabels(values []string) string {
+	var labels string
+	for i, name := range f.labels {
+		labels = appendLabel(labels, name, values[i])
+	}
+	return labels
+}
+
+func appendLabel(labels string, name string, value string) string {
+	if labels != "" {
+		labels += ","
+	}
+	return labels + name + `="` + labelValueEscaper.Replace(value) + `"`
+}
+
+func wrapLabels(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (w *countingWriter) WriteString(s string) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.WriteString(s)
+	w.n += int64(n)
+	w.err = err
+}
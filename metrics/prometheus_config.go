@@ -0,0 +1,41 @@
+package metrics
+
+// DefaultPrometheusConfig returns a PrometheusConfig with sensible defaults.
+func DefaultPrometheusConfig() *PrometheusConfig {
+	return &PrometheusConfig{
+		Namespace: "disgo",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}
+}
+
+// PrometheusConfig lets you configure your PrometheusExporter instance.
+type PrometheusConfig struct {
+	// Namespace is prepended to all metric names. Defaults to "disgo".
+	Namespace string
+	// Buckets are the upper bounds in seconds of the histogram buckets. Defaults to 5ms up to 10s.
+	Buckets []float64
+}
+
+// PrometheusConfigOpt is a type alias for a function that takes a PrometheusConfig and is used to configure your PrometheusExporter.
+type PrometheusConfigOpt func(config *PrometheusConfig)
+
+// Apply applies the given PrometheusConfigOpt(s) to the PrometheusConfig
+func (c *PrometheusConfig) Apply(opts []PrometheusConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithNamespace sets the Namespace of the PrometheusExporter.
+func WithNamespace(namespace string) PrometheusConfigOpt {
+	return func(config *PrometheusConfig) {
+		config.Namespace = namespace
+	}
+}
+
+// WithBuckets sets the histogram Buckets of the PrometheusExporter.
+func WithBuckets(buckets ...float64) PrometheusConfigOpt {
+	return func(config *PrometheusConfig) {
+		config.Buckets = buckets
+	}
+}
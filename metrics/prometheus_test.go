@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusExporter(t *testing.T) {
+	e := NewPrometheusExporter(WithBuckets(0.5, 0.1, 1))
+
+	e.GatewayEventReceived(0, "MESSAGE_CREATE")
+	e.GatewayEventReceived(0, "MESSAGE_CREATE")
+	e.GatewayEventReceived(1, "GUILD_CREATE")
+	e.GatewayHeartbeatLatency(0, 42*time.Millisecond)
+	e.RestRequest("GET", "/channels/{channel.id}", 200, 50*time.Millisecond)
+	e.RestRequest("GET", "/channels/{channel.id}", 200, 700*time.Millisecond)
+	e.RestRateLimited("POST", "/channels/{channel.id}/messages", true)
+	e.RegisterCacheSizes(func() map[string]int { return map[string]int{"guilds": 3} })
+	e.RegisterCacheSizes(func() map[string]int { return map[string]int{"guilds": 2} })
+	e.HandlerCompleted("component", `/vote/"{id}"`, 2*time.Second, errors.New("failed"))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, body, "# TYPE disgo_gateway_events_total counter\n")
+	assert.Contains(t, body, `disgo_gateway_events_total{shard_id="0",event_type="MESSAGE_CREATE"} 2`+"\n")
+	assert.Contains(t, body, `disgo_gateway_events_total{shard_id="1",event_type="GUILD_CREATE"} 1`+"\n")
+	assert.Contains(t, body, `disgo_gateway_heartbeat_latency_seconds{shard_id="0"} 0.042`+"\n")
+	assert.NotContains(t, body, "disgo_gateway_reconnects_total", "metrics without samples should be omitted")
+
+	assert.Contains(t, body, `disgo_rest_requests_total{method="GET",route="/channels/{channel.id}",status="200"} 2`+"\n")
+	assert.Contains(t, body, `disgo_rest_request_duration_seconds_bucket{method="GET",route="/channels/{channel.id}",le="0.1"} 1`+"\n")
+	assert.Contains(t, body, `disgo_rest_request_duration_seconds_bucket{method="GET",route="/channels/{channel.id}",le="0.5"} 1`+"\n")
+	assert.Contains(t, body, `disgo_rest_request_duration_seconds_bucket{method="GET",route="/channels/{channel.id}",le="1"} 2`+"\n")
+	assert.Contains(t, body, `disgo_rest_request_duration_seconds_bucket{method="GET",route="/channels/{channel.id}",le="+Inf"} 2`+"\n")
+	assert.Contains(t, body, `disgo_rest_request_duration_seconds_count{method="GET",route="/channels/{channel.id}"} 2`+"\n")
+	assert.Contains(t, body, `disgo_rest_rate_limited_total{method="POST",route="/channels/{channel.id}/messages",global="true"} 1`+"\n")
+
+	assert.Contains(t, body, `disgo_cache_entities{cache="guilds"} 5`+"\n")
+
+	assert.Contains(t, body, `disgo_handler_duration_seconds_bucket{type="component",route="/vote/\"{id}\"",le="1"} 0`+"\n")
+	assert.Contains(t, body, `disgo_handler_duration_seconds_sum{type="component",route="/vote/\"{id}\""} 2`+"\n")
+	assert.Contains(t, body, `disgo_handler_errors_total{type="component",route="/vote/\"{id}\""} 1`+"\n")
+}
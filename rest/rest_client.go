@@ -13,6 +13,7 @@ import (
 	"github.com/disgoorg/json"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 // NewClient constructs a new Client with the given Config struct
@@ -116,12 +117,28 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		}
 	}
 
+	tracer := c.config.Tracer
+	if tracer == nil {
+		tracer = tracing.TracerFromContext(config.Ctx)
+	}
+	ctx, span := tracer.Start(config.Ctx, "rest.request",
+		tracing.String("http.method", endpoint.Endpoint.Method),
+		tracing.String("http.route", endpoint.Endpoint.Route),
+		tracing.Int("rest.try", tries),
+	)
+	defer span.End()
+
 	// wait for rate limits
-	err = c.RateLimiter().WaitBucket(config.Ctx, endpoint)
+	waitStart := time.Now()
+	waitCtx, waitSpan := tracer.Start(ctx, "rest.rate_limit_wait")
+	err = c.RateLimiter().WaitBucket(waitCtx, endpoint)
+	waitSpan.RecordError(err)
+	waitSpan.End()
 	if err != nil {
+		span.RecordError(err)
 		return fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
-	rq = rq.WithContext(config.Ctx)
+	c.config.Metrics.RestRateLimitWait(endpoint.Endpoint.Method, endpoint.Endpoint.Route, time.Since(waitStart))
 
 	for _, check := range config.Checks {
 		if !check() {
@@ -130,11 +147,16 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		}
 	}
 
+	start := time.Now()
 	rs, err := c.HTTPClient().Do(config.Request)
 	if err != nil {
+		c.config.Metrics.RestRequest(endpoint.Endpoint.Method, endpoint.Endpoint.Route, 0, time.Since(start))
+		span.RecordError(err)
 		_ = c.RateLimiter().UnlockBucket(endpoint, nil)
 		return fmt.Errorf("error doing request in rest client: %w", err)
 	}
+	c.config.Metrics.RestRequest(endpoint.Endpoint.Method, endpoint.Endpoint.Route, rs.StatusCode, time.Since(start))
+	span.SetAttributes(tracing.Int("http.status_code", rs.StatusCode))
 
 	if err = c.RateLimiter().UnlockBucket(endpoint, rs); err != nil {
 		return fmt.Errorf("error unlocking bucket in rest client: %w", err)
@@ -159,13 +181,20 @@ func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, t
 		return nil
 
 	case http.StatusTooManyRequests:
+		global := rs.Header.Get("X-RateLimit-Global") != ""
+		c.config.Metrics.RestRateLimited(endpoint.Endpoint.Method, endpoint.Endpoint.Route, global)
+		span.AddEvent("rate_limited", tracing.Bool("rest.global", global))
 		if tries >= c.RateLimiter().MaxRetries() {
-			return NewError(rq, rawRqBody, rs, rawRsBody)
+			err = NewError(rq, rawRqBody, rs, rawRsBody)
+			span.RecordError(err)
+			return err
 		}
 		return c.retry(endpoint, rqBody, rsBody, tries+1, opts)
 
 	default:
-		return NewError(rq, rawRqBody, rs, rawRsBody)
+		err = NewError(rq, rawRqBody, rs, rawRsBody)
+		span.RecordError(err)
+		return err
 	}
 }
 
@@ -5,6 +5,9 @@ import (
 	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/disgoorg/disgo/metrics"
+	"github.com/disgoorg/disgo/tracing"
 )
 
 // DefaultConfig is the configuration which is used by default
@@ -13,6 +16,7 @@ func DefaultConfig() *Config {
 		Logger:     slog.Default(),
 		HTTPClient: &http.Client{Timeout: 20 * time.Second},
 		URL:        fmt.Sprintf("%sv%d", API, Version),
+		Metrics:    metrics.Noop,
 	}
 }
 
@@ -24,6 +28,9 @@ type Config struct {
 	RateLimiterConfigOpts []RateLimiterConfigOpt
 	URL                   string
 	UserAgent             string
+	Metrics               metrics.Recorder
+	// Tracer is used to trace all requests. Defaults to the tracing.Tracer carried by the context.Context of the request, see tracing.ContextWithTracer.
+	Tracer tracing.Tracer
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -80,3 +87,17 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithMetrics sets the metrics.Recorder which receives request counts, durations and rate limit waits
+func WithMetrics(recorder metrics.Recorder) ConfigOpt {
+	return func(config *Config) {
+		config.Metrics = recorder
+	}
+}
+
+// WithTracer sets the tracing.Tracer which traces all requests and rate limit waits
+func WithTracer(tracer tracing.Tracer) ConfigOpt {
+	return func(config *Config) {
+		config.Tracer = tracer
+	}
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+// ErrJobNotFound is returned by Scheduler.Cancel when no Job with the given ID exists.
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrSchedulerClosed is returned when scheduling a Job on a closed Scheduler.
+var ErrSchedulerClosed = errors.New("scheduler closed")
+
+// HandlerFunc runs a Job. The context.Context is cancelled when the Scheduler is closed.
+// Returned errors are logged, one-shot Job(s) are removed regardless.
+type HandlerFunc func(ctx context.Context, job Job) error
+
+// Job is a persisted task which calls the HandlerFunc registered for its Handler once or repeatedly.
+// Since functions can't be persisted, a Job only references its HandlerFunc by name and carries its arguments as JSON Data.
+type Job struct {
+	ID string `json:"id"`
+	// Handler is the name of the HandlerFunc registered with Scheduler.Handle.
+	Handler string `json:"handler"`
+	// Data is the JSON encoded data passed when scheduling the Job.
+	Data json.RawMessage `json:"data,omitempty"`
+	// RunAt is the next time the Job runs.
+	RunAt time.Time `json:"run_at"`
+	// Schedule is the cron expression of recurring Job(s), see ParseSchedule. It is empty for one-shot Job(s).
+	Schedule  string    `json:"schedule,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Recurring returns whether the Job runs repeatedly.
+func (j Job) Recurring() bool {
+	return j.Schedule != ""
+}
+
+// Unmarshal decodes the Data of the Job into v.
+func (j Job) Unmarshal(v any) error {
+	if len(j.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(j.Data, v)
+}
+
+// Scheduler runs Job(s) at a specific time or on a recurring Schedule.
+// All Job(s) are persisted in a Store and resumed after a restart. Job(s) which were due while the bot was offline run once immediately.
+// Job(s) only run once a HandlerFunc for them was registered.
+type Scheduler interface {
+	// Handle registers the HandlerFunc for the given name. Register all handlers on startup, so persisted Job(s) can run.
+	Handle(name string, handler HandlerFunc)
+
+	// ScheduleAt schedules a one-shot Job for the handler at the given time. The data is encoded as JSON.
+	ScheduleAt(handler string, runAt time.Time, data any) (Job, error)
+
+	// ScheduleIn schedules a one-shot Job for the handler after the given duration. The data is encoded as JSON.
+	ScheduleIn(handler string, in time.Duration, data any) (Job, error)
+
+	// ScheduleCron schedules a recurring Job for the handler with the given cron expression, see ParseSchedule. The data is encoded as JSON.
+	ScheduleCron(handler string, expr string, data any) (Job, error)
+
+	// Cancel removes the Job with the given ID. It returns ErrJobNotFound if no Job with the ID exists.
+	Cancel(id string) error
+
+	// Job returns the Job with the given ID.
+	Job(id string) (Job, bool)
+
+	// Jobs returns all scheduled Job(s) ordered by their next run.
+	Jobs() []Job
+
+	// Close stops the Scheduler and waits for running Job(s) until the context.Context is done.
+	// Job(s) stay persisted in the Store and are resumed on the next start.
+	Close(ctx context.Context)
+}
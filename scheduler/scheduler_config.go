@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"log/slog"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:   slog.Default(),
+		FilePath: "scheduler.json",
+	}
+}
+
+// Config lets you configure your Scheduler instance.
+type Config struct {
+	// Logger is the logger of the Scheduler. Defaults to slog.Default()
+	Logger *slog.Logger
+	// Store is the Store the Job(s) are persisted in. Defaults to NewFileStore(FilePath).
+	Store Store
+	// FilePath is the path of the file used by the default Store. Defaults to "scheduler.json".
+	FilePath string
+	// Handlers are the HandlerFunc(s) registered on creation.
+	Handlers map[string]HandlerFunc
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Scheduler.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewFileStore(c.FilePath)
+	}
+}
+
+// WithLogger sets the logger of the Scheduler.
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithStore sets the Store of the Scheduler.
+func WithStore(store Store) ConfigOpt {
+	return func(config *Config) {
+		config.Store = store
+	}
+}
+
+// WithFilePath sets the path of the file used by the default Store.
+func WithFilePath(path string) ConfigOpt {
+	return func(config *Config) {
+		config.FilePath = path
+	}
+}
+
+// WithHandler registers the HandlerFunc for the given name on creation. See Scheduler.Handle.
+func WithHandler(name string, handler HandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		if config.Handlers == nil {
+			config.Handlers = map[string]HandlerFunc{}
+		}
+		config.Handlers[name] = handler
+	}
+}
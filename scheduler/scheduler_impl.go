@@ -0,0 +1,306 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+var _ Scheduler = (*schedulerImpl)(nil)
+
+// New creates a new Scheduler with the given ConfigOpt(s) applied and resumes all Job(s) persisted in the Store.
+func New(opts ...ConfigOpt) Scheduler {
+	cfg := DefaultConfig()
+	cfg.Apply(opts)
+	cfg.Logger = cfg.Logger.With(slog.String("name", "scheduler"))
+
+	loopCtx, loopCancel := context.WithCancel(context.Background())
+	jobCtx, jobCancel := context.WithCancel(context.Background())
+	s := &schedulerImpl{
+		config:     *cfg,
+		handlers:   map[string]HandlerFunc{},
+		jobs:       map[string]Job{},
+		running:    map[string]struct{}{},
+		wake:       make(chan struct{}, 1),
+		loopCtx:    loopCtx,
+		loopCancel: loopCancel,
+		jobCtx:     jobCtx,
+		jobCancel:  jobCancel,
+	}
+	for name, handler := range cfg.Handlers {
+		s.handlers[name] = handler
+	}
+
+	jobs, err := cfg.Store.Load()
+	if err != nil {
+		cfg.Logger.Error("failed to load persisted jobs", slog.Any("err", err))
+	}
+	for _, job := range jobs {
+		s.jobs[job.ID] = job
+	}
+
+	s.loopWg.Add(1)
+	go s.loop()
+	return s
+}
+
+type schedulerImpl struct {
+	config Config
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	jobs     map[string]Job
+	running  map[string]struct{}
+	closed   bool
+
+	wake       chan struct{}
+	loopCtx    context.Context
+	loopCancel context.CancelFunc
+	loopWg     sync.WaitGroup
+	jobCtx     context.Context
+	jobCancel  context.CancelFunc
+	jobWg      sync.WaitGroup
+}
+
+func (s *schedulerImpl) Handle(name string, handler HandlerFunc) {
+	s.mu.Lock()
+	s.handlers[name] = handler
+	s.mu.Unlock()
+	s.notify()
+}
+
+func (s *schedulerImpl) ScheduleAt(handler string, runAt time.Time, data any) (Job, error) {
+	return s.schedule(handler, runAt, "", data)
+}
+
+func (s *schedulerImpl) ScheduleIn(handler string, in time.Duration, data any) (Job, error) {
+	return s.schedule(handler, time.Now().Add(in), "", data)
+}
+
+func (s *schedulerImpl) ScheduleCron(handler string, expr string, data any) (Job, error) {
+	schedule, err := ParseSchedule(expr)
+	if err != nil {
+		return Job{}, err
+	}
+	runAt := schedule.Next(time.Now())
+	if runAt.IsZero() {
+		return Job{}, fmt.Errorf("schedule %q never runs", expr)
+	}
+	return s.schedule(handler, runAt, expr, data)
+}
+
+func (s *schedulerImpl) schedule(handler string, runAt time.Time, expr string, data any) (Job, error) {
+	var rawData json.RawMessage
+	if data != nil {
+		var err error
+		if rawData, err = json.Marshal(data); err != nil {
+			return Job{}, fmt.Errorf("failed to encode job data: %w", err)
+		}
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+	job := Job{
+		ID:        id,
+		Handler:   handler,
+		Data:      rawData,
+		RunAt:     runAt,
+		Schedule:  expr,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return Job{}, ErrSchedulerClosed
+	}
+	if err = s.config.Store.Save(job); err != nil {
+		s.mu.Unlock()
+		return Job{}, fmt.Errorf("failed to persist job: %w", err)
+	}
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	s.notify()
+	return job, nil
+}
+
+func (s *schedulerImpl) Cancel(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[id]; !ok {
+		return ErrJobNotFound
+	}
+	if err := s.config.Store.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+	delete(s.jobs, id)
+	return nil
+}
+
+func (s *schedulerImpl) Job(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+func (s *schedulerImpl) Jobs() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedJobs(s.jobs)
+}
+
+func (s *schedulerImpl) Close(ctx context.Context) {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.loopCancel()
+	s.loopWg.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		s.jobWg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.config.Logger.Warn("closing scheduler while jobs are still running")
+	}
+	s.jobCancel()
+}
+
+func (s *schedulerImpl) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *schedulerImpl) loop() {
+	defer s.loopWg.Done()
+
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var timerC <-chan time.Time
+		if next, ok := s.runDue(); ok {
+			timer.Reset(time.Until(next))
+			timerC = timer.C
+		}
+
+		select {
+		case <-s.loopCtx.Done():
+			return
+		case <-s.wake:
+		case <-timerC:
+		}
+	}
+}
+
+// runDue starts all due Job(s) and returns when the next Job is due.
+func (s *schedulerImpl) runDue() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		now  = time.Now()
+		next time.Time
+	)
+	for id, job := range s.jobs {
+		if _, ok := s.running[id]; ok {
+			continue
+		}
+		handler, ok := s.handlers[job.Handler]
+		if !ok {
+			continue
+		}
+		if job.RunAt.After(now) {
+			if next.IsZero() || job.RunAt.Before(next) {
+				next = job.RunAt
+			}
+			continue
+		}
+
+		s.running[id] = struct{}{}
+		s.jobWg.Add(1)
+		go s.run(job, handler)
+	}
+	return next, !next.IsZero()
+}
+
+func (s *schedulerImpl) run(job Job, handler HandlerFunc) {
+	defer s.jobWg.Done()
+	defer s.notify()
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.config.Logger.Error("recovered from panic in job handler", slog.String("job_id", job.ID), slog.String("handler", job.Handler), slog.Any("arg", r), slog.String("stack", string(debug.Stack())))
+			}
+		}()
+		if err := handler(s.jobCtx, job); err != nil {
+			s.config.Logger.Error("failed to run job", slog.String("job_id", job.ID), slog.String("handler", job.Handler), slog.Any("err", err))
+		}
+	}()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, job.ID)
+
+	// the job was cancelled while running
+	if _, ok := s.jobs[job.ID]; !ok {
+		return
+	}
+
+	if job.Recurring() {
+		if next := s.nextRun(job); !next.IsZero() {
+			job.RunAt = next
+			s.jobs[job.ID] = job
+			if err := s.config.Store.Save(job); err != nil {
+				s.config.Logger.Error("failed to persist job", slog.String("job_id", job.ID), slog.Any("err", err))
+			}
+			return
+		}
+	}
+
+	delete(s.jobs, job.ID)
+	if err := s.config.Store.Delete(job.ID); err != nil {
+		s.config.Logger.Error("failed to delete job", slog.String("job_id", job.ID), slog.Any("err", err))
+	}
+}
+
+// nextRun returns the next run of a recurring Job. Runs missed while the Job was running or the bot was offline are skipped.
+func (s *schedulerImpl) nextRun(job Job) time.Time {
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		s.config.Logger.Error("failed to parse job schedule", slog.String("job_id", job.ID), slog.String("schedule", job.Schedule), slog.Any("err", err))
+		return time.Time{}
+	}
+	return schedule.Next(time.Now())
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/disgoorg/json"
+)
+
+// Store persists Job(s), so they survive restarts of the bot.
+// All methods are called from multiple goroutines.
+type Store interface {
+	// Load returns all persisted Job(s).
+	Load() ([]Job, error)
+
+	// Save creates or updates the given Job.
+	Save(job Job) error
+
+	// Delete deletes the Job with the given ID. Deleting a Job which doesn't exist is not an error.
+	Delete(id string) error
+}
+
+var (
+	_ Store = (*memoryStore)(nil)
+	_ Store = (*fileStore)(nil)
+)
+
+// NewMemoryStore returns a Store which keeps all Job(s) in memory. Job(s) are lost on restart.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		jobs: map[string]Job{},
+	}
+}
+
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+func (s *memoryStore) Load() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedJobs(s.jobs), nil
+}
+
+func (s *memoryStore) Save(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+	return nil
+}
+
+func (s *memoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// NewFileStore returns a Store which keeps all Job(s) in a JSON file at the given path.
+// The file is only created once the first Job is saved and is replaced atomically on every change.
+func NewFileStore(path string) Store {
+	return &fileStore{
+		path: path,
+	}
+}
+
+type fileStore struct {
+	path string
+
+	mu   sync.Mutex
+	jobs map[string]Job
+}
+
+func (s *fileStore) Load() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return sortedJobs(s.jobs), nil
+}
+
+func (s *fileStore) Save(job Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	s.jobs[job.ID] = job
+	return s.write()
+}
+
+func (s *fileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.jobs[id]; !ok {
+		return nil
+	}
+	delete(s.jobs, id)
+	return s.write()
+}
+
+// load reads the file once, missing files are treated as empty.
+func (s *fileStore) load() error {
+	if s.jobs != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.jobs = map[string]Job{}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read scheduler file: %w", err)
+	}
+
+	var jobs []Job
+	if err = json.Unmarshal(data, &jobs); err != nil {
+		return fmt.Errorf("failed to decode scheduler file: %w", err)
+	}
+	s.jobs = make(map[string]Job, len(jobs))
+	for _, job := range jobs {
+		s.jobs[job.ID] = job
+	}
+	return nil
+}
+
+func (s *fileStore) write() error {
+	data, err := json.MarshalIndent(sortedJobs(s.jobs), "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to encode scheduler file: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create scheduler file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write scheduler file: %w", err)
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write scheduler file: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write scheduler file: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace scheduler file: %w", err)
+	}
+	return nil
+}
+
+func sortedJobs(jobs map[string]Job) []Job {
+	sorted := make([]Job, 0, len(jobs))
+	for _, job := range jobs {
+		sorted = append(sorted, job)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].RunAt.Equal(sorted[j].RunAt) {
+			return sorted[i].RunAt.Before(sorted[j].RunAt)
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	base := time.Date(2024, time.January, 31, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, time.January, 31, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17 * * *", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1,3", time.Date(2024, time.February, 5, 8, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 0", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", base.Add(90 * time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(base))
+		})
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "@every 1ms"} {
+		_, err := ParseSchedule(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestSchedulerResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+
+	s := New(WithFilePath(path))
+	job, err := s.ScheduleIn("remind", 20*time.Millisecond, "hello")
+	require.NoError(t, err)
+	_, err = s.ScheduleCron("cleanup", "@hourly", nil)
+	require.NoError(t, err)
+	s.Close(context.Background())
+
+	ran := make(chan Job, 1)
+	s = New(WithFilePath(path), WithHandler("remind", func(ctx context.Context, job Job) error {
+		ran <- job
+		return nil
+	}))
+	defer s.Close(context.Background())
+	assert.Len(t, s.Jobs(), 2, "jobs should be loaded from the file")
+
+	select {
+	case resumed := <-ran:
+		assert.Equal(t, job.ID, resumed.ID)
+		var data string
+		assert.NoError(t, resumed.Unmarshal(&data))
+		assert.Equal(t, "hello", data)
+	case <-time.After(time.Second):
+		t.Fatal("persisted job did not run")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, ok := s.Job(job.ID)
+		return !ok
+	}, time.Second, 5*time.Millisecond, "one-shot jobs should be removed after running")
+	assert.Len(t, s.Jobs(), 1)
+
+	jobs, err := NewFileStore(path).Load()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
@@ -3,6 +3,8 @@ package sharding
 import (
 	"log/slog"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/disgoorg/disgo/gateway"
 )
 
@@ -12,6 +14,7 @@ func DefaultConfig() *Config {
 		Logger:            slog.Default(),
 		GatewayCreateFunc: gateway.New,
 		ShardSplitCount:   ShardSplitCount,
+		CloseCode:         websocket.CloseNormalClosure,
 	}
 }
 
@@ -27,6 +30,8 @@ type Config struct {
 	ShardSplitCount int
 	// AutoScaling will automatically re-shard shards if they are too large. This is disabled by default.
 	AutoScaling bool
+	// CloseCode is the websocket close code the shards are closed with by ShardManager.Close. Defaults to websocket.CloseNormalClosure which invalidates the sessions.
+	CloseCode int
 	// GatewayCreateFunc is the function which is used by the ShardManager to create a new gateway.Gateway. Defaults to gateway.New.
 	GatewayCreateFunc gateway.CreateFunc
 	// GatewayConfigOpts are the ConfigOpt(s) which are applied to the gateway.Gateway.
@@ -91,6 +96,14 @@ func WithAutoScaling(autoScaling bool) ConfigOpt {
 	}
 }
 
+// WithCloseCode sets the websocket close code the shards are closed with by ShardManager.Close.
+// Use websocket.CloseServiceRestart to keep the sessions resumable.
+func WithCloseCode(closeCode int) ConfigOpt {
+	return func(config *Config) {
+		config.CloseCode = closeCode
+	}
+}
+
 // WithGatewayCreateFunc sets the function which is used by the ShardManager to create a new gateway.Gateway.
 func WithGatewayCreateFunc(gatewayCreateFunc gateway.CreateFunc) ConfigOpt {
 	return func(config *Config) {
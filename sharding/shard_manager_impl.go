@@ -131,7 +131,7 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			shard.Close(ctx)
+			shard.CloseWithCode(ctx, m.config.CloseCode, "Shutting down")
 		}()
 	}
 	wg.Wait()
@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var _ Tracer = (*MemoryTracer)(nil)
+
+// NewMemoryTracer returns a Tracer which keeps all ended Span(s) in memory. It is meant for tests.
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+// MemoryTracer is a Tracer which records all ended Span(s) as RecordedSpan(s).
+type MemoryTracer struct {
+	nextID atomic.Uint64
+
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// RecordedSpan is an ended Span recorded by the MemoryTracer.
+type RecordedSpan struct {
+	Name string
+	// TraceID is the SpanID of the root Span of the trace.
+	TraceID uint64
+	SpanID  uint64
+	// ParentID is the SpanID of the parent Span or 0 for root Span(s).
+	ParentID   uint64
+	Start      time.Time
+	End        time.Time
+	Attributes []Attribute
+	Events     []RecordedEvent
+	Errors     []error
+}
+
+// Duration returns how long the Span took.
+func (s RecordedSpan) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// Attribute returns the value of the last Attribute with the given key.
+func (s RecordedSpan) Attribute(key string) (any, bool) {
+	for i := len(s.Attributes) - 1; i >= 0; i-- {
+		if s.Attributes[i].Key == key {
+			return s.Attributes[i].Value, true
+		}
+	}
+	return nil, false
+}
+
+// RecordedEvent is an event added to a RecordedSpan.
+type RecordedEvent struct {
+	Name       string
+	Time       time.Time
+	Attributes []Attribute
+}
+
+type memorySpanKey struct{}
+
+func (t *MemoryTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &memorySpan{
+		tracer: t,
+		span: RecordedSpan{
+			Name:       name,
+			SpanID:     t.nextID.Add(1),
+			Start:      time.Now(),
+			Attributes: append([]Attribute(nil), attrs...),
+		},
+	}
+	if parent, ok := ctx.Value(memorySpanKey{}).(*memorySpan); ok {
+		span.span.TraceID = parent.span.TraceID
+		span.span.ParentID = parent.span.SpanID
+	} else {
+		span.span.TraceID = span.span.SpanID
+	}
+	return context.WithValue(ctx, memorySpanKey{}, span), span
+}
+
+// Spans returns all ended Span(s) in the order they ended.
+func (t *MemoryTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]RecordedSpan(nil), t.spans...)
+}
+
+// SpansByName returns all ended Span(s) with the given name.
+func (t *MemoryTracer) SpansByName(name string) []RecordedSpan {
+	var spans []RecordedSpan
+	for _, span := range t.Spans() {
+		if span.Name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// Reset removes all recorded Span(s).
+func (t *MemoryTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+type memorySpan struct {
+	tracer *MemoryTracer
+
+	mu    sync.Mutex
+	span  RecordedSpan
+	ended bool
+}
+
+func (s *memorySpan) SetAttributes(attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Attributes = append(s.span.Attributes, attrs...)
+}
+
+func (s *memorySpan) AddEvent(name string, attrs ...Attribute) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Events = append(s.span.Events, RecordedEvent{
+		Name:       name,
+		Time:       time.Now(),
+		Attributes: attrs,
+	})
+}
+
+func (s *memorySpan) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Errors = append(s.span.Errors, err)
+}
+
+func (s *memorySpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.span.End = time.Now()
+	span := s.span
+	s.mu.Unlock()
+
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, span)
+}
@@ -0,0 +1,100 @@
+// Package tracing provides a minimal tracing abstraction which follows an interaction from the events.InteractionCreate
+// through the handler.Mux, its middlewares and every rest.Client request made with the context.Context of the interaction.
+//
+// The Tracer and Span interfaces mirror the OpenTelemetry API, so an adapter for go.opentelemetry.io/otel/trace only needs to forward the calls:
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string, attrs ...tracing.Attribute) (context.Context, tracing.Span) {
+//		ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(convertAttributes(attrs)...))
+//		return ctx, otelSpan{span}
+//	}
+//
+// Use NewMemoryTracer to record spans in tests.
+package tracing
+
+import (
+	"context"
+)
+
+// Tracer starts new Span(s). The returned context.Context carries the new Span, so Span(s) started with it become its children.
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a single timed operation of a trace.
+type Span interface {
+	// SetAttributes adds or overrides attributes of the Span.
+	SetAttributes(attrs ...Attribute)
+
+	// AddEvent records a named point in time during the Span.
+	AddEvent(name string, attrs ...Attribute)
+
+	// RecordError marks the Span as failed with the given error. Nil errors are ignored.
+	RecordError(err error)
+
+	// End finishes the Span. Calls after the first one are ignored.
+	End()
+}
+
+// Attribute is a key-value pair attached to a Span.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string Attribute.
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an int Attribute.
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int64 returns an int64 Attribute.
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a bool Attribute.
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Noop is a Tracer which does not record anything.
+var Noop Tracer = noopTracer{}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute)    {}
+func (noopSpan) AddEvent(string, ...Attribute) {}
+func (noopSpan) RecordError(error)             {}
+func (noopSpan) End()                          {}
+
+type tracerKey struct{}
+
+// ContextWithTracer returns a copy of the context.Context which carries the Tracer.
+// The handler.Mux uses this to pass its Tracer to sub-routers and the rest.Client.
+func ContextWithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// TracerFromContext returns the Tracer carried by the context.Context or Noop.
+func TracerFromContext(ctx context.Context) Tracer {
+	if ctx == nil {
+		return Noop
+	}
+	if tracer, ok := ctx.Value(tracerKey{}).(Tracer); ok {
+		return tracer
+	}
+	return Noop
+}